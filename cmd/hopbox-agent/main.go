@@ -36,12 +36,32 @@ func main() {
 	if meta := os.Getenv("BOX_META"); meta != "" {
 		go heartbeatLoop(meta) // F3: report load to the metadata API for idle detection
 	}
+	wait := minRetry
 	for {
-		if err := connectAndServe(addr, agentproto.Handshake{WorkspaceID: wsID, Token: token}); err != nil {
-			log.Printf("hopbox-agent: connection ended: %v; retrying in 2s", err)
-		}
-		time.Sleep(2 * time.Second) // reconnect with simple backoff
+		start := time.Now()
+		err := connectAndServe(addr, agentproto.Handshake{WorkspaceID: wsID, Token: token, Protocol: agentproto.Protocol})
+		wait = nextRetry(wait, time.Since(start))
+		log.Printf("hopbox-agent: connection ended: %v; retrying in %s", err, wait)
+		time.Sleep(wait)
+	}
+}
+
+// Reconnect backoff bounds. A refused agent (bad token, incompatible protocol)
+// backs off to maxRetry instead of hammering hopboxd and its log every 2s.
+const (
+	minRetry = 2 * time.Second
+	maxRetry = 30 * time.Second
+)
+
+// nextRetry is the wait before the next dial, given the last wait and how long
+// the connection that just ended lasted. A session that stayed up past maxRetry
+// was a real one (say, ended by a suspend or a hopboxd restart): reconnect
+// promptly. Otherwise double the wait, up to maxRetry.
+func nextRetry(last, lived time.Duration) time.Duration {
+	if lived > maxRetry || last < minRetry {
+		return minRetry
 	}
+	return min(2*last, maxRetry)
 }
 
 // heartbeatLoop reports the box's 1-minute load average to the metadata API
@@ -161,7 +181,9 @@ func (e *execWriter) Write(p []byte) (int, error) {
 }
 
 // handleExec runs an argv command without a pty and streams stdout/stderr back
-// as exec frames, then the exit code. No stdin in v1.
+// as exec frames, then the exit code. Like a shell session it runs in the
+// workspace home with HOME set, so `hopbox exec` / `hopbox shell -c` see the
+// same environment as an interactive shell.
 func handleExec(stream io.ReadWriteCloser) {
 	hdr, err := agentproto.ReadExecHeader(stream)
 	if err != nil {
@@ -174,7 +196,9 @@ func handleExec(stream io.ReadWriteCloser) {
 	}
 	var mu sync.Mutex
 	cmd := exec.Command(hdr.Cmd[0], hdr.Cmd[1:]...)
-	cmd.Env = append(os.Environ(), "TERM=dumb")
+	home := workspaceHome()
+	cmd.Dir = home
+	cmd.Env = append(envWithout(os.Environ(), "HOME"), "TERM=dumb", "HOME="+home)
 	cmd.Stdout = &execWriter{w: stream, typ: agentproto.ExecStdout, mu: &mu}
 	cmd.Stderr = &execWriter{w: stream, typ: agentproto.ExecStderr, mu: &mu}
 	stdin, err := cmd.StdinPipe()
@@ -237,8 +261,15 @@ func handleForward(stream io.ReadWriteCloser) {
 		return
 	}
 	defer conn.Close()
-	go func() { _, _ = io.Copy(conn, stream) }() // gateway -> service
-	_, _ = io.Copy(stream, conn)                 // service -> gateway
+	go func() {
+		_, _ = io.Copy(conn, stream) // gateway -> service
+		// The far side half-closed: let the service see EOF, and keep relaying
+		// its reply below.
+		if tc, ok := conn.(*net.TCPConn); ok {
+			_ = tc.CloseWrite()
+		}
+	}()
+	_, _ = io.Copy(stream, conn) // service -> gateway
 }
 
 // handleShell reads a ShellHeader, then bridges a pty to the stream.
@@ -163,3 +163,16 @@ func TestHandleStreamNoGoroutineLeak(t *testing.T) {
 	_ = ctrlSess.Close()
 	_ = agentSess.Close()
 }
+
+func TestNextRetryBacksOff(t *testing.T) {
+	wait := minRetry
+	for range 10 {
+		wait = nextRetry(wait, time.Millisecond) // refused straight away
+	}
+	if wait != maxRetry {
+		t.Fatalf("repeated refusals: wait %s, want %s", wait, maxRetry)
+	}
+	if got := nextRetry(wait, time.Hour); got != minRetry {
+		t.Fatalf("after a long session: wait %s, want %s", got, minRetry)
+	}
+}
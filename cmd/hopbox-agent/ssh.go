@@ -4,6 +4,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 
 	"github.com/hopboxdev/hopbox/internal/agentssh"
 )
@@ -19,7 +20,9 @@ var agentSSH agentssh.Config
 func loadSSHConfig() {
 	keyPath := os.Getenv("HOPBOX_SSH_HOST_KEY")
 	if keyPath == "" {
-		keyPath = "/var/lib/hopbox/ssh_host_ed25519_key"
+		dir := stateDir()
+		migrateState(legacyStateDir, dir, "ssh_host_ed25519_key")
+		keyPath = filepath.Join(dir, "ssh_host_ed25519_key")
 	}
 	signer, err := agentssh.LoadOrCreateHostKey(keyPath)
 	if err != nil {
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// legacyStateDir is where the agent always kept its state before stateDir; it
+// is still the default for a root agent (PID 1 in a microVM, a container
+// entrypoint).
+const legacyStateDir = "/var/lib/hopbox"
+
+// stateDir resolves the directory for the agent's persistent state (the SSH
+// host key): HOPBOX_STATE_DIR if set, /var/lib/hopbox when running as root, else
+// $XDG_STATE_HOME/hopbox (~/.local/state/hopbox), so a non-root agent never
+// needs write access to system paths.
+func stateDir() string {
+	if d := os.Getenv("HOPBOX_STATE_DIR"); d != "" {
+		return d
+	}
+	if os.Geteuid() == 0 {
+		return legacyStateDir
+	}
+	if d := os.Getenv("XDG_STATE_HOME"); d != "" {
+		return filepath.Join(d, "hopbox")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".local", "state", "hopbox")
+	}
+	return legacyStateDir
+}
+
+// migrateState copies name from the legacy dir into dir when dir has no copy
+// yet, so moving the state dir keeps the SSH host key (and with it every
+// client's known_hosts entry) stable. The legacy file is left in place: it may
+// be read-only to this agent. Best-effort; failures are logged.
+func migrateState(legacy, dir, name string) {
+	if legacy == dir {
+		return
+	}
+	dst := filepath.Join(dir, name)
+	if _, err := os.Stat(dst); !errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	b, err := os.ReadFile(filepath.Join(legacy, name))
+	if err != nil {
+		return // nothing to migrate (or not readable by us)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		log.Printf("hopbox-agent: state migrate %s: %v", name, err)
+		return
+	}
+	if err := os.WriteFile(dst, b, 0o600); err != nil {
+		log.Printf("hopbox-agent: state migrate %s: %v", name, err)
+		return
+	}
+	log.Printf("hopbox-agent: migrated %s from %s to %s", name, legacy, dir)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStateDirEnvOverride(t *testing.T) {
+	t.Setenv("HOPBOX_STATE_DIR", "/srv/hopbox")
+	if got := stateDir(); got != "/srv/hopbox" {
+		t.Fatalf("stateDir = %q", got)
+	}
+}
+
+// A host key in the legacy dir is copied into a new, empty state dir, and never
+// overwrites one already there.
+func TestMigrateStateCopiesOnce(t *testing.T) {
+	legacy, dir := t.TempDir(), filepath.Join(t.TempDir(), "state")
+	if err := os.WriteFile(filepath.Join(legacy, "key"), []byte("old"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	migrateState(legacy, dir, "key")
+	if b, err := os.ReadFile(filepath.Join(dir, "key")); err != nil || string(b) != "old" {
+		t.Fatalf("migrated key = %q, %v", b, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "key"), []byte("new"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	migrateState(legacy, dir, "key")
+	if b, _ := os.ReadFile(filepath.Join(dir, "key")); string(b) != "new" {
+		t.Fatalf("existing key overwritten: %q", b)
+	}
+}
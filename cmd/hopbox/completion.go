@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	hopboxv1 "github.com/hopboxdev/hopbox/gen/hopbox/v1"
+)
+
+// Dynamic values for `hopbox completion bash|zsh|fish|powershell`. A completion
+// must never hang the shell or print into it, so lookups are short and offer
+// nothing on any error.
+
+// completeWorkspaceArg completes a command's first argument with workspace names.
+func completeWorkspaceArg(cmd *cobra.Command, args []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return workspaceCompletions(cmd, nil), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeWorkspaceArgs completes every argument with workspace names, leaving
+// out those already given.
+func completeWorkspaceArgs(cmd *cobra.Command, args []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return workspaceCompletions(cmd, args), cobra.ShellCompDirectiveNoFileComp
+}
+
+// workspaceCompletions lists the server's workspaces, described by phase.
+func workspaceCompletions(cmd *cobra.Command, skip []string) []cobra.Completion {
+	// Completion runs through cobra's hidden __complete command, which skips
+	// the root's PersistentPreRunE: load the address, context and token here.
+	if err := applyConfig(cmd.Flags()); err != nil {
+		return nil
+	}
+	client, closer, err := dial()
+	if err != nil {
+		return nil
+	}
+	defer closer()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	resp, err := client.ListWorkspaces(ctx, &hopboxv1.ListWorkspacesRequest{})
+	if err != nil {
+		return nil
+	}
+	var out []cobra.Completion
+	for _, w := range resp.Workspaces {
+		if !slices.Contains(skip, w.Name) {
+			out = append(out, cobra.CompletionWithDesc(w.Name, w.Phase))
+		}
+	}
+	return out
+}
+
+// completeConfigKey completes `config get|set|unset` keys, and for set the
+// values of keys that have a fixed set of them.
+func completeConfigKey(cmd *cobra.Command, args []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		var out []cobra.Completion
+		for _, k := range sortedKeys() {
+			out = append(out, cobra.CompletionWithDesc(k, configKeys[k].help))
+		}
+		return out, cobra.ShellCompDirectiveNoFileComp
+	}
+	if len(args) == 1 && cmd.Name() == "set" {
+		switch args[0] {
+		case "tls":
+			return []cobra.Completion{"true", "false"}, cobra.ShellCompDirectiveNoFileComp
+		case "tls-ca":
+			return nil, cobra.ShellCompDirectiveDefault // a file
+		}
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeContextArg completes a command's first argument with context names.
+func completeContextArg(_ *cobra.Command, args []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return contextCompletions(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeContextFlag completes --context.
+func completeContextFlag(*cobra.Command, []string, string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return contextCompletions(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// contextCompletions lists the configured contexts, described by server.
+func contextCompletions() []cobra.Completion {
+	c, err := loadConfig()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(c.Contexts))
+	for n := range c.Contexts {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	out := make([]cobra.Completion, 0, len(names))
+	for _, n := range names {
+		if x := c.Contexts[n]; x != nil && x.Addr != "" {
+			out = append(out, cobra.CompletionWithDesc(n, x.Addr))
+		} else {
+			out = append(out, n)
+		}
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	hopboxv1 "github.com/hopboxdev/hopbox/gen/hopbox/v1"
+)
+
+type listServer struct {
+	hopboxv1.UnimplementedWorkspaceServiceServer
+}
+
+func (listServer) ListWorkspaces(context.Context, *hopboxv1.ListWorkspacesRequest) (*hopboxv1.ListWorkspacesResponse, error) {
+	return &hopboxv1.ListWorkspacesResponse{Workspaces: []*hopboxv1.Workspace{
+		{Name: "api", Phase: "Running"}, {Name: "web", Phase: "Suspended"},
+	}}, nil
+}
+
+// Completion bypasses the root's PersistentPreRunE, so workspace completion
+// must resolve the server from the config itself.
+func TestWorkspaceCompletions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() {
+		apiAddr, contextName, activeCtx, cfg = "", "", nil, &cliConfig{}
+		apiTLS, apiTLSCA = false, ""
+	})
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs := grpc.NewServer()
+	hopboxv1.RegisterWorkspaceServiceServer(gs, listServer{})
+	go func() { _ = gs.Serve(ln) }()
+	defer gs.Stop()
+	if err := saveConfig(&cliConfig{Contexts: map[string]*cliContext{"lab": {Addr: ln.Addr().String()}}, CurrentContext: "lab"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := &cobra.Command{Use: "wait"}
+	cmd.Flags().StringVar(&apiAddr, "addr", "localhost:7700", "")
+	cmd.Flags().BoolVar(&apiTLS, "tls", false, "")
+	cmd.Flags().StringVar(&apiTLSCA, "tls-ca", "", "")
+	cmd.Flags().StringVar(&contextName, "context", "", "")
+	got, _ := completeWorkspaceArgs(cmd, []string{"api"}, "")
+	if want := []cobra.Completion{"web\tSuspended"}; !slices.Equal(got, want) {
+		t.Fatalf("completions = %q, want %q", got, want)
+	}
+	if got := contextCompletions(); !slices.Equal(got, []cobra.Completion{"lab\t" + ln.Addr().String()}) {
+		t.Fatalf("context completions = %q", got)
+	}
+}
@@ -0,0 +1,453 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// cliConfig is the CLI's persistent settings (~/.hopbox/config.json): defaults
+// for values otherwise passed as flags on every command. Flags always win.
+type cliConfig struct {
+	Version int `json:"version,omitempty"` // schema version (see configMigrations)
+
+	Addr  string `json:"addr,omitempty"`   // hopboxd API address (--addr)
+	User  string `json:"user,omitempty"`   // remote SSH user (ssh / ssh-config --user)
+	Image string `json:"image,omitempty"`  // image for `hopbox create` (--image)
+	MemMB int64  `json:"mem_mb,omitempty"` // memory limit for `hopbox create` (--mem-mb)
+	TLS   bool   `json:"tls,omitempty"`    // dial hopboxd over TLS (--tls)
+	TLSCA string `json:"tls_ca,omitempty"` // CA bundle to verify hopboxd's certificate (--tls-ca)
+
+	// Contexts are named server profiles (`hopbox context`); the current one's
+	// values take precedence over the plain keys above.
+	Contexts       map[string]*cliContext `json:"contexts,omitempty"`
+	CurrentContext string                 `json:"current_context,omitempty"`
+
+	// diskVersion is the schema version of the file this was loaded from, so
+	// saveConfig knows to back up a file it is about to upgrade.
+	diskVersion int
+}
+
+// configKey describes one settable key: how to read, validate+write, and clear it.
+type configKey struct {
+	help  string
+	def   string // built-in default shown by `config list` ("" = none)
+	get   func(*cliConfig) string
+	set   func(*cliConfig, string) error
+	unset func(*cliConfig)
+}
+
+var configKeys = map[string]configKey{
+	"addr": {
+		help: "hopboxd API address (host:port)", def: "localhost:7700",
+		get: func(c *cliConfig) string { return c.Addr },
+		set: func(c *cliConfig, v string) error {
+			if _, _, err := net.SplitHostPort(v); err != nil {
+				return fmt.Errorf("addr %q: want host:port", v)
+			}
+			c.Addr = v
+			return nil
+		},
+		unset: func(c *cliConfig) { c.Addr = "" },
+	},
+	"user": {
+		help: "remote user for ssh / ssh-config (default: your login principal)", def: "dev",
+		get: func(c *cliConfig) string { return c.User },
+		set: func(c *cliConfig, v string) error {
+			if v == "" {
+				return fmt.Errorf("user must not be empty")
+			}
+			c.User = v
+			return nil
+		},
+		unset: func(c *cliConfig) { c.User = "" },
+	},
+	"image": {
+		help: "default image for hopbox create", def: "ubuntu:24.04",
+		get: func(c *cliConfig) string { return c.Image },
+		set: func(c *cliConfig, v string) error {
+			if v == "" {
+				return fmt.Errorf("image must not be empty")
+			}
+			c.Image = v
+			return nil
+		},
+		unset: func(c *cliConfig) { c.Image = "" },
+	},
+	"tls": {
+		help: "dial hopboxd over TLS (true/false)", def: "false",
+		get: func(c *cliConfig) string {
+			if !c.TLS {
+				return ""
+			}
+			return "true"
+		},
+		set: func(c *cliConfig, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("tls %q: want true or false", v)
+			}
+			c.TLS = b
+			return nil
+		},
+		unset: func(c *cliConfig) { c.TLS = false },
+	},
+	"tls-ca": {
+		help: "PEM CA bundle to verify hopboxd's certificate (implies tls)",
+		get:  func(c *cliConfig) string { return c.TLSCA },
+		set: func(c *cliConfig, v string) error {
+			if _, err := os.Stat(v); err != nil {
+				return fmt.Errorf("tls-ca: %w", err)
+			}
+			abs, err := filepath.Abs(v)
+			if err != nil {
+				return err
+			}
+			c.TLSCA = abs
+			return nil
+		},
+		unset: func(c *cliConfig) { c.TLSCA = "" },
+	},
+	"mem-mb": {
+		help: "default memory limit in MB for hopbox create (0=unlimited)", def: "0",
+		get: func(c *cliConfig) string {
+			if c.MemMB == 0 {
+				return ""
+			}
+			return strconv.FormatInt(c.MemMB, 10)
+		},
+		set: func(c *cliConfig, v string) error {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n < 0 {
+				return fmt.Errorf("mem-mb %q: want a non-negative integer", v)
+			}
+			c.MemMB = n
+			return nil
+		},
+		unset: func(c *cliConfig) { c.MemMB = 0 },
+	},
+}
+
+func configPath() (string, error) {
+	d, err := hopboxDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d, "config.json"), nil
+}
+
+// loadConfig reads the CLI config, upgrading an older schema in memory (the
+// file itself is rewritten on the next save); a missing file is an empty config.
+func loadConfig() (*cliConfig, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &cliConfig{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c, err := decodeConfig(b)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// saveConfig writes the config atomically (temp file + rename), so an
+// interrupted write never leaves a truncated file behind. A file from an older
+// schema version is backed up first.
+func saveConfig(c *cliConfig) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	if err := backupConfig(path, c.diskVersion); err != nil {
+		return err
+	}
+	c.Version = configVersion
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".config-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+	if _, err := tmp.Write(append(b, '\n')); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	c.diskVersion = configVersion
+	return nil
+}
+
+// cfg is the loaded CLI config, applied once flags are parsed (see applyConfig).
+// activeCtx is its selected context, nil when none is in use.
+var (
+	cfg       = &cliConfig{}
+	activeCtx *cliContext
+)
+
+// errUnknownContext is returned by applyConfig when the selected context does
+// not exist. The other values are still applied, so commands that manage the
+// config itself can go ahead (see managesConfig).
+var errUnknownContext = errors.New("unknown context")
+
+// applyConfig loads the config, selects the active context and fills in global
+// flag values the user did not pass explicitly.
+func applyConfig(flags *pflag.FlagSet) error {
+	c, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	cfg = c
+	name := contextName
+	if !flags.Changed("context") && name == "" {
+		name = cfg.CurrentContext
+	}
+	activeCtx = nil
+	var ctxErr error
+	if name != "" {
+		ctx, ok := cfg.Contexts[name]
+		switch {
+		case !ok:
+			ctxErr = fmt.Errorf("%w %q (see: hopbox context ls)", errUnknownContext, name)
+		case ctx == nil: // "name": null in a hand-edited file
+			ctx = &cliContext{}
+			fallthrough
+		default:
+			contextName, activeCtx = name, ctx
+		}
+	}
+	if !flags.Changed("addr") {
+		switch {
+		case activeCtx != nil && activeCtx.Addr != "":
+			apiAddr = activeCtx.Addr
+		case cfg.Addr != "":
+			apiAddr = cfg.Addr
+		}
+	}
+	// TLS settings describe the server, so a context's replace the plain keys
+	// wholesale rather than field by field.
+	if !flags.Changed("tls") && !flags.Changed("tls-ca") {
+		if activeCtx != nil && activeCtx.Addr != "" {
+			apiTLS, apiTLSCA = activeCtx.TLS, activeCtx.TLSCA
+		} else {
+			apiTLS, apiTLSCA = cfg.TLS, cfg.TLSCA
+		}
+	}
+	return ctxErr
+}
+
+// managesConfig reports whether cmd is one of the `context` or `config`
+// subcommands, which must run even when the selected context does not exist
+// yet — that is how it gets created.
+func managesConfig(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if p := c.Parent(); p != nil && !p.HasParent() {
+			return c.Name() == "context" || c.Name() == "config"
+		}
+	}
+	return false
+}
+
+// defaultUser is the remote user when --user is not given: the active context's
+// user, the configured user, the principal from `hopbox login`, else "dev".
+func defaultUser() string {
+	if activeCtx != nil && activeCtx.User != "" {
+		return activeCtx.User
+	}
+	if cfg.User != "" {
+		return cfg.User
+	}
+	if p := readPrincipal(); p != "" {
+		return p
+	}
+	return "dev"
+}
+
+// configValueJSON is one key in `config get|set|unset --json`: the value now
+// in the file, "" when unset.
+type configValueJSON struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func newConfigCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "config",
+		Short: "Get and set CLI defaults (~/.hopbox/config.json)",
+	}
+	c.AddCommand(&cobra.Command{
+		Use:               "get <key>",
+		Short:             "Print a configured value",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeConfigKey,
+		RunE: func(_ *cobra.Command, args []string) error {
+			k, ok := configKeys[args[0]]
+			if !ok {
+				return unknownKey(args[0])
+			}
+			c, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if jsonOut {
+				return printJSON(configValueJSON{args[0], k.get(c)})
+			}
+			fmt.Println(k.get(c))
+			return nil
+		},
+	}, &cobra.Command{
+		Use:               "set <key> <value>",
+		Short:             "Set a value",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeConfigKey,
+		RunE: func(_ *cobra.Command, args []string) error {
+			k, ok := configKeys[args[0]]
+			if !ok {
+				return unknownKey(args[0])
+			}
+			c, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if err := k.set(c, args[1]); err != nil {
+				return err
+			}
+			if err := saveConfig(c); err != nil {
+				return err
+			}
+			if jsonOut {
+				return printJSON(configValueJSON{args[0], k.get(c)})
+			}
+			return nil
+		},
+	}, &cobra.Command{
+		Use:               "unset <key>",
+		Short:             "Clear a value (fall back to the default)",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeConfigKey,
+		RunE: func(_ *cobra.Command, args []string) error {
+			k, ok := configKeys[args[0]]
+			if !ok {
+				return unknownKey(args[0])
+			}
+			c, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			k.unset(c)
+			if err := saveConfig(c); err != nil {
+				return err
+			}
+			if jsonOut {
+				return printJSON(configValueJSON{args[0], k.get(c)})
+			}
+			return nil
+		},
+	}, &cobra.Command{
+		Use:   "list",
+		Short: "Show effective values and where each comes from",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			c, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			type row struct {
+				Key         string `json:"key"`
+				Value       string `json:"value"`
+				Source      string `json:"source"` // "" when unset with no default
+				Description string `json:"description"`
+			}
+			var rows []row
+			tw := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+			if !jsonOut {
+				fmt.Fprintln(tw, "KEY\tVALUE\tSOURCE\tDESCRIPTION")
+			}
+			for _, name := range sortedKeys() {
+				k := configKeys[name]
+				val, src := k.get(c), "config"
+				// The TLS keys travel as a pair: a flag for either, or a context
+				// with a server, supplies both (see applyConfig).
+				isTLS := name == "tls" || name == "tls-ca"
+				switch {
+				case name == "addr" && cmd.Flags().Changed("addr"):
+					val, src = apiAddr, "flag"
+				case name == "addr" && activeCtx != nil && activeCtx.Addr != "":
+					val, src = activeCtx.Addr, "context "+contextName
+				case isTLS && (cmd.Flags().Changed("tls") || cmd.Flags().Changed("tls-ca")):
+					val, src = effectiveTLS(name), "flag"
+				case isTLS && activeCtx != nil && activeCtx.Addr != "":
+					val, src = effectiveTLS(name), "context "+contextName
+				case name == "user" && activeCtx != nil && activeCtx.User != "":
+					val, src = activeCtx.User, "context "+contextName
+				case val != "":
+				case name == "user" && readPrincipal() != "":
+					val, src = readPrincipal(), "login"
+				case k.def != "":
+					val, src = k.def, "default"
+				default:
+					val, src = "", ""
+				}
+				if jsonOut {
+					rows = append(rows, row{Key: name, Value: val, Source: src, Description: k.help})
+					continue
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", name, orDash(val), orDash(src), k.help)
+			}
+			if jsonOut {
+				return printJSON(rows)
+			}
+			return tw.Flush()
+		},
+	}, newConfigMigrateCmd())
+	return c
+}
+
+// effectiveTLS is the applied value of the tls or tls-ca key, as `config list`
+// shows it.
+func effectiveTLS(key string) string {
+	if key == "tls-ca" {
+		return apiTLSCA
+	}
+	return strconv.FormatBool(apiTLS || apiTLSCA != "")
+}
+
+func sortedKeys() []string {
+	names := make([]string, 0, len(configKeys))
+	for n := range configKeys {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func unknownKey(name string) error {
+	return fmt.Errorf("unknown config key %q (known: %v)", name, sortedKeys())
+}
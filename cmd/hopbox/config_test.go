@@ -0,0 +1,247 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// set validates per key and round-trips through the config file.
+func TestConfigSetRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	c, err := loadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := configKeys["addr"].set(c, "no-port"); err == nil {
+		t.Fatal("addr without a port should be rejected")
+	}
+	if err := configKeys["mem-mb"].set(c, "-1"); err == nil {
+		t.Fatal("negative mem-mb should be rejected")
+	}
+	for k, v := range map[string]string{"addr": "hopbox.lan:7700", "user": "alice", "image": "debian:12", "mem-mb": "4096"} {
+		if err := configKeys[k].set(c, v); err != nil {
+			t.Fatalf("set %s: %v", k, err)
+		}
+	}
+	if err := saveConfig(c); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Addr != "hopbox.lan:7700" || got.User != "alice" || got.Image != "debian:12" || got.MemMB != 4096 {
+		t.Fatalf("reloaded config = %+v", got)
+	}
+	path, _ := configPath()
+	if fi, err := os.Stat(path); err != nil || fi.Mode().Perm() != 0o600 {
+		t.Fatalf("config file mode: %v %v", fi, err)
+	}
+	if left, _ := filepath.Glob(filepath.Join(filepath.Dir(path), ".config-*")); len(left) != 0 {
+		t.Fatalf("temp files left behind: %v", left)
+	}
+}
+
+// The active context overrides the plain config keys; an explicit flag
+// overrides both.
+func TestApplyConfigContext(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("HOPBOX_TOKEN", "")
+	t.Cleanup(func() {
+		apiAddr, contextName, activeCtx, cfg = "", "", nil, &cliConfig{}
+		apiTLS, apiTLSCA = false, ""
+	})
+	if err := saveConfig(&cliConfig{
+		Addr: "plain:7700", User: "plain",
+		Contexts: map[string]*cliContext{
+			"prod": {Addr: "prod:7700", User: "alice", Token: "tok", TLSCA: "/etc/hopbox/ca.pem"},
+			"lab":  {Addr: "lab:7700"},
+		},
+		CurrentContext: "prod",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	apply := func(args ...string) {
+		t.Helper()
+		fs := pflag.NewFlagSet("hopbox", pflag.ContinueOnError)
+		fs.StringVar(&apiAddr, "addr", "localhost:7700", "")
+		fs.StringVar(&contextName, "context", "", "")
+		if err := fs.Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		if err := applyConfig(fs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	apply()
+	if apiAddr != "prod:7700" || defaultUser() != "alice" || readToken() != "tok" || apiTLSCA != "/etc/hopbox/ca.pem" {
+		t.Fatalf("current context: addr=%s user=%s token=%q tls-ca=%q", apiAddr, defaultUser(), readToken(), apiTLSCA)
+	}
+	d, _ := hopboxDir()
+	if err := os.WriteFile(filepath.Join(d, "token"), []byte("default-tok"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	apply("--context", "lab")
+	if apiAddr != "lab:7700" || defaultUser() != "plain" || readToken() != "" || apiTLSCA != "" {
+		t.Fatalf("--context lab: addr=%s user=%s token=%q tls-ca=%q", apiAddr, defaultUser(), readToken(), apiTLSCA)
+	}
+	apply("--context", "lab", "--addr", "flag:1")
+	if apiAddr != "flag:1" {
+		t.Fatalf("--addr should win over the context, got %s", apiAddr)
+	}
+	t.Setenv("HOPBOX_TOKEN", "ci-tok")
+	apply()
+	if readToken() != "tok" {
+		t.Fatalf("the context's token should win over $HOPBOX_TOKEN, got %q", readToken())
+	}
+	apply("--context", "lab")
+	if readToken() != "ci-tok" {
+		t.Fatalf("$HOPBOX_TOKEN should fill in for a context without a token, got %q", readToken())
+	}
+	fs := pflag.NewFlagSet("hopbox", pflag.ContinueOnError)
+	fs.StringVar(&apiAddr, "addr", "localhost:7700", "")
+	fs.StringVar(&contextName, "context", "", "")
+	_ = fs.Parse([]string{"--context", "nope"})
+	if err := applyConfig(fs); !errors.Is(err, errUnknownContext) {
+		t.Fatalf("unknown context: err = %v", err)
+	}
+	if apiAddr != "plain:7700" || activeCtx != nil {
+		t.Fatalf("unknown context should still apply the plain keys: addr=%s ctx=%v", apiAddr, activeCtx)
+	}
+}
+
+// Only the context and config subcommands run with an unknown context, so
+// `hopbox --context new context set new ...` can create it.
+func TestManagesConfig(t *testing.T) {
+	root := &cobra.Command{Use: "hopbox"}
+	ctx, set := &cobra.Command{Use: "context"}, &cobra.Command{Use: "set"}
+	conf, list := &cobra.Command{Use: "config"}, &cobra.Command{Use: "list"}
+	ls := &cobra.Command{Use: "ls"}
+	ctx.AddCommand(set)
+	conf.AddCommand(list)
+	root.AddCommand(ctx, conf, ls)
+	for cmd, want := range map[*cobra.Command]bool{set: true, ctx: true, list: true, ls: false, root: false} {
+		if got := managesConfig(cmd); got != want {
+			t.Errorf("managesConfig(%s) = %v, want %v", cmd.CommandPath(), got, want)
+		}
+	}
+}
+
+// An unversioned file is read through the migrations, and the first save backs
+// it up before writing the current version; a later migration step sees the
+// raw keys.
+func TestConfigMigrate(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path, err := configPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := `{"addr":"a:1","mem_mb":512}`
+	if err := os.WriteFile(path, []byte(old), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := loadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Addr != "a:1" || c.MemMB != 512 || c.diskVersion != 0 {
+		t.Fatalf("migrated config = %+v", c)
+	}
+	if err := saveConfig(c); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(configBackupPath(path, 0)); err != nil || string(b) != old {
+		t.Fatalf("backup = %q, %v", b, err)
+	}
+	if b, _ := os.ReadFile(path); !strings.Contains(string(b), `"version": 1`) {
+		t.Fatalf("saved config has no version 1: %s", b)
+	}
+	if c, err = loadConfig(); err != nil || c.Version != configVersion || c.diskVersion != configVersion {
+		t.Fatalf("reloaded config = %+v, %v", c, err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version":99}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadConfig(); err == nil {
+		t.Fatal("a config from a newer version should be rejected")
+	}
+
+	// A future step renaming a key works on the raw JSON.
+	saved := configMigrations
+	t.Cleanup(func() { configMigrations, configVersion = saved, len(saved) })
+	configMigrations = append(saved[:len(saved):len(saved)], func(raw map[string]json.RawMessage) error {
+		raw["mem_mb"] = raw["memory"]
+		delete(raw, "memory")
+		return nil
+	})
+	configVersion = len(configMigrations)
+	if c, err = decodeConfig([]byte(`{"version":1,"memory":256}`)); err != nil || c.MemMB != 256 || c.diskVersion != 1 {
+		t.Fatalf("renamed key: %+v, %v", c, err)
+	}
+}
+
+// Each context keeps its own login credentials, and a null context entry in a
+// hand-edited file is an empty context rather than a crash.
+func TestContextCredDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { contextName, activeCtx, cfg = "", nil, &cliConfig{} })
+	if err := os.WriteFile(mustConfigPath(t), []byte(`{"contexts":{"prod":{"addr":"p:1"},"lab":null}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	paths := map[string]string{}
+	for _, name := range []string{"", "prod", "lab"} {
+		fs := pflag.NewFlagSet("hopbox", pflag.ContinueOnError)
+		fs.StringVar(&contextName, "context", "", "")
+		if err := fs.Parse([]string{"--context=" + name}); err != nil {
+			t.Fatal(err)
+		}
+		if err := applyConfig(fs); err != nil {
+			t.Fatalf("context %q: %v", name, err)
+		}
+		p, err := identityKeyPath()
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths[name] = p
+	}
+	home, _ := hopboxDir()
+	if paths[""] != filepath.Join(home, "id_ed25519") || paths["prod"] != filepath.Join(home, "contexts", "prod", "id_ed25519") || paths["lab"] == paths["prod"] {
+		t.Fatalf("identity paths = %v", paths)
+	}
+	for _, bad := range []string{"", "..", "a/b"} {
+		if checkContextName(bad) == nil {
+			t.Errorf("context name %q should be rejected", bad)
+		}
+	}
+}
+
+func mustConfigPath(t *testing.T) string {
+	t.Helper()
+	p, err := configPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// --token - takes the first line of stdin, so tokens stay off the command line.
+func TestTokenFrom(t *testing.T) {
+	if tok, err := tokenFrom(strings.NewReader("  s3cret \nignored\n")); err != nil || tok != "s3cret" {
+		t.Fatalf("tokenFrom = %q, %v", tok, err)
+	}
+	if _, err := tokenFrom(strings.NewReader("\n")); err == nil {
+		t.Fatal("an empty stdin should be an error, not an empty token")
+	}
+	if tok, _ := tokenFlag("literal"); tok != "literal" {
+		t.Fatalf("tokenFlag(literal) = %q", tok)
+	}
+}
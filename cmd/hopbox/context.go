@@ -0,0 +1,292 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// cliContext is a named server profile, kubeconfig-style: which hopboxd to talk
+// to and as whom. Switching contexts replaces --addr, the api token and the SSH
+// user in one step.
+type cliContext struct {
+	Addr  string `json:"addr,omitempty"`   // hopboxd API address
+	User  string `json:"user,omitempty"`   // remote SSH user
+	Token string `json:"token,omitempty"`  // api token for multi-user servers
+	TLS   bool   `json:"tls,omitempty"`    // dial over TLS
+	TLSCA string `json:"tls_ca,omitempty"` // CA bundle to verify the server (implies TLS)
+}
+
+// contextName is the --context flag (or HOPBOX_CONTEXT); once applyConfig runs
+// it names the active context, "" if none.
+var contextName string
+
+func newContextCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "context",
+		Short: "Manage named server contexts (address, user, token)",
+	}
+	c.AddCommand(newContextSetCmd(), &cobra.Command{
+		Use:               "use <name>",
+		Short:             "Make a context the current one",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeContextArg,
+		RunE: func(_ *cobra.Command, args []string) error {
+			c, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if _, ok := c.Contexts[args[0]]; !ok {
+				return fmt.Errorf("unknown context %q (see: hopbox context ls)", args[0])
+			}
+			c.CurrentContext = args[0]
+			if err := saveConfig(c); err != nil {
+				return err
+			}
+			if jsonOut {
+				return printJSON(map[string]string{"context": args[0]})
+			}
+			fmt.Printf("switched to context %q\n", args[0])
+			return nil
+		},
+	}, &cobra.Command{
+		Use:   "ls",
+		Short: "List contexts (* marks the active one)",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if jsonOut {
+				return printJSON(contextsJSON())
+			}
+			if len(cfg.Contexts) == 0 {
+				fmt.Println("no contexts (create one: hopbox context set <name> --server host:port)")
+				return nil
+			}
+			names := make([]string, 0, len(cfg.Contexts))
+			for n := range cfg.Contexts {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			tw := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+			fmt.Fprintln(tw, "CURRENT\tNAME\tSERVER\tTLS\tUSER\tTOKEN")
+			for _, n := range names {
+				x, mark := cfg.Contexts[n], ""
+				if x == nil {
+					x = &cliContext{}
+				}
+				if n == contextName {
+					mark = "*"
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", mark, n, orDash(x.Addr), tlsNote(x.TLS, x.TLSCA), orDash(x.User), tokenNote(x.Token))
+			}
+			return tw.Flush()
+		},
+	}, &cobra.Command{
+		Use:               "show [name]",
+		Short:             "Show a context (default: the active one)",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeContextArg,
+		RunE: func(_ *cobra.Command, args []string) error {
+			name := contextName
+			if len(args) == 1 {
+				name = args[0]
+			}
+			if name == "" {
+				if jsonOut {
+					return printJSON(nil)
+				}
+				fmt.Println("no context in use")
+				return nil
+			}
+			x, ok := cfg.Contexts[name]
+			if !ok {
+				return fmt.Errorf("unknown context %q", name)
+			}
+			if x == nil {
+				x = &cliContext{}
+			}
+			if jsonOut {
+				return printJSON(newContextJSON(name, x))
+			}
+			fmt.Printf("name:   %s\nserver: %s\ntls:    %s\nuser:   %s\ntoken:  %s\n", name, orDash(x.Addr), tlsNote(x.TLS, x.TLSCA), orDash(x.User), tokenNote(x.Token))
+			return nil
+		},
+	}, &cobra.Command{
+		Use:               "rm <name>",
+		Short:             "Delete a context",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeContextArg,
+		RunE: func(_ *cobra.Command, args []string) error {
+			c, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if _, ok := c.Contexts[args[0]]; !ok {
+				return fmt.Errorf("unknown context %q", args[0])
+			}
+			delete(c.Contexts, args[0])
+			if c.CurrentContext == args[0] {
+				c.CurrentContext = ""
+			}
+			if err := saveConfig(c); err != nil {
+				return err
+			}
+			if checkContextName(args[0]) == nil { // else it never had a credentials dir
+				d, err := hopboxDir()
+				if err != nil {
+					return err
+				}
+				if err := os.RemoveAll(filepath.Join(d, "contexts", args[0])); err != nil {
+					return err
+				}
+			}
+			if jsonOut {
+				return printJSON(map[string]any{"context": args[0], "removed": true})
+			}
+			return nil
+		},
+	})
+	return c
+}
+
+// newContextSetCmd creates a context or updates the fields given as flags.
+func newContextSetCmd() *cobra.Command {
+	var server, user, token, tlsCA string
+	var useTLS bool
+	c := &cobra.Command{
+		Use:               "set <name>",
+		Short:             "Create or update a context",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeContextArg,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkContextName(args[0]); err != nil {
+				return err
+			}
+			c, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			x := c.Contexts[args[0]]
+			if x == nil {
+				x = &cliContext{}
+			}
+			if cmd.Flags().Changed("server") {
+				if _, _, err := net.SplitHostPort(server); err != nil {
+					return fmt.Errorf("server %q: want host:port", server)
+				}
+				x.Addr = server
+			}
+			if cmd.Flags().Changed("user") {
+				x.User = user
+			}
+			if cmd.Flags().Changed("token") {
+				if x.Token, err = tokenFlag(token); err != nil {
+					return err
+				}
+			}
+			if cmd.Flags().Changed("tls") {
+				x.TLS = useTLS
+			}
+			if cmd.Flags().Changed("tls-ca") {
+				x.TLSCA = ""
+				if tlsCA != "" {
+					abs, err := filepath.Abs(tlsCA)
+					if err != nil {
+						return err
+					}
+					x.TLSCA = abs
+				}
+			}
+			if c.Contexts == nil {
+				c.Contexts = map[string]*cliContext{}
+			}
+			c.Contexts[args[0]] = x
+			if err := saveConfig(c); err != nil {
+				return err
+			}
+			if jsonOut {
+				return printJSON(newContextJSON(args[0], x))
+			}
+			return nil
+		},
+	}
+	c.Flags().StringVar(&server, "server", "", "hopboxd API address (host:port)")
+	c.Flags().StringVar(&user, "user", "", "remote SSH user")
+	c.Flags().StringVar(&token, "token", "", "api token for multi-user servers; - reads it from stdin (keeps it out of ps and shell history)")
+	c.Flags().BoolVar(&useTLS, "tls", false, "dial the server over TLS")
+	c.Flags().StringVar(&tlsCA, "tls-ca", "", "PEM CA bundle to verify the server's certificate (implies --tls)")
+	return c
+}
+
+// contextJSON is one context in `context ls|show --json`; the token itself is
+// never printed.
+type contextJSON struct {
+	Name     string `json:"name"`
+	Current  bool   `json:"current"`
+	Server   string `json:"server"`
+	TLS      bool   `json:"tls"`
+	TLSCA    string `json:"tls_ca"`
+	User     string `json:"user"`
+	TokenSet bool   `json:"token_set"`
+}
+
+func newContextJSON(name string, x *cliContext) contextJSON {
+	return contextJSON{
+		Name: name, Current: name == contextName, Server: x.Addr, TLS: x.TLS || x.TLSCA != "",
+		TLSCA: x.TLSCA, User: x.User, TokenSet: x.Token != "",
+	}
+}
+
+// contextsJSON lists the configured contexts, sorted by name.
+func contextsJSON() []contextJSON {
+	out := make([]contextJSON, 0, len(cfg.Contexts))
+	for n, x := range cfg.Contexts {
+		if x == nil {
+			x = &cliContext{}
+		}
+		out = append(out, newContextJSON(n, x))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// checkContextName rejects names that cannot be a directory under
+// ~/.hopbox/contexts, where each context keeps its own credentials.
+func checkContextName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("context name %q: must not be empty, . or .., or contain a slash", name)
+	}
+	return nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// tlsNote summarizes a context's transport: "-" (plaintext), "on" (system
+// roots) or the CA bundle it verifies against.
+func tlsNote(on bool, ca string) string {
+	switch {
+	case ca != "":
+		return ca
+	case on:
+		return "on"
+	}
+	return "-"
+}
+
+// tokenNote reports whether a token is set without printing it.
+func tokenNote(tok string) string {
+	if tok == "" {
+		return "-"
+	}
+	return "(set)"
+}
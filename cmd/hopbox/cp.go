@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// splitRemote reports whether an scp operand names a workspace path
+// ("<name>:<path>" or "<user>@<name>:<path>"). As with scp, a colon after a
+// slash is part of a local path, so ./a:b stays local.
+func splitRemote(op string) (host, path string, ok bool) {
+	i := strings.IndexByte(op, ':')
+	if i <= 0 || strings.ContainsRune(op[:i], '/') {
+		return "", "", false
+	}
+	return op[:i], op[i+1:], true
+}
+
+// scpArgs builds the scp command line for copying operands (sources then the
+// destination): workspace operands get the default user, and every host is
+// reached through `hopbox proxy` via the %h ProxyCommand, so several
+// workspaces can appear in one copy.
+func scpArgs(operands []string, user string, recursive bool) ([]string, error) {
+	args := proxyOpts("%h")
+	if recursive {
+		args = append(args, "-r")
+	}
+	remote := false
+	for _, op := range operands {
+		if host, path, ok := splitRemote(op); ok {
+			remote = true
+			if !strings.Contains(host, "@") {
+				op = user + "@" + host + ":" + path
+			}
+		}
+		args = append(args, op)
+	}
+	if !remote {
+		return nil, fmt.Errorf("no workspace path: write remote paths as <name>:<path>")
+	}
+	return args, nil
+}
+
+// newCpCmd copies files between the local machine and workspaces by running
+// the system scp through `hopbox proxy`, like `hopbox ssh` does for ssh.
+func newCpCmd() *cobra.Command {
+	var (
+		user      string
+		recursive bool
+	)
+	c := &cobra.Command{
+		Use:   "cp [-r] <src>... <dst>",
+		Short: "Copy files to or from a workspace (wraps the system scp)",
+		Long: `Copy files to or from a workspace. Workspace paths are written <name>:<path>,
+relative to the workspace home unless absolute:
+
+  hopbox cp ./dump.sql api:/tmp/
+  hopbox cp -r api:project/dist ./dist`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if user == "" {
+				user = defaultUser()
+			}
+			argv, err := scpArgs(args, user, recursive)
+			if err != nil {
+				return err
+			}
+			scp := exec.Command("scp", argv...)
+			scp.Stdin, scp.Stdout, scp.Stderr = os.Stdin, os.Stdout, os.Stderr
+			return scp.Run()
+		},
+	}
+	c.Flags().BoolVarP(&recursive, "recursive", "r", false, "copy directories recursively")
+	c.Flags().StringVar(&user, "user", "", "remote user (default: config user, else your principal from `hopbox login`)")
+	return c
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSplitRemote(t *testing.T) {
+	for op, want := range map[string]string{
+		"api:/tmp/x":    "api|/tmp/x",
+		"bob@api:notes": "bob@api|notes",
+		"api:":          "api|",
+		"./a:b":         "",
+		"/abs/a:b":      "",
+		"plain.txt":     "",
+		":odd":          "",
+	} {
+		host, path, ok := splitRemote(op)
+		got := ""
+		if ok {
+			got = host + "|" + path
+		}
+		if got != want {
+			t.Errorf("splitRemote(%q) = %q, want %q", op, got, want)
+		}
+	}
+}
+
+// Workspace operands gain the default user; local ones and explicit users are
+// left alone; a copy with no workspace side is refused.
+func TestScpArgs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	args, err := scpArgs([]string{"./a", "bob@b:/x", "api:dst/"}, "dev", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tail := args[len(args)-4:]; !slices.Equal(tail, []string{"-r", "./a", "bob@b:/x", "dev@api:dst/"}) {
+		t.Fatalf("operands = %q", tail)
+	}
+	if _, err := scpArgs([]string{"a", "b"}, "dev", false); err == nil {
+		t.Fatal("a local-only copy should be refused")
+	}
+}
+
+// The proxy subprocess must reach the same server, over the same transport and
+// with the same context's token, as the command that spawned it.
+func TestProxyCommandCarriesServer(t *testing.T) {
+	t.Cleanup(func() { apiAddr, contextName, apiTLS, apiTLSCA = "", "", false, "" })
+	apiAddr, contextName, apiTLS, apiTLSCA = "prod:7700", "prod", true, "/etc/hopbox/ca.pem"
+	got := proxyCommand("%h")
+	for _, want := range []string{" proxy %h --addr prod:7700", ` --context "prod"`, ` --tls-ca "/etc/hopbox/ca.pem"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("proxyCommand = %s, missing %s", got, want)
+		}
+	}
+}
@@ -15,9 +15,10 @@ import (
 // stdout/stderr to the terminal and exiting with the command's exit code.
 func newExecCmd(dial func() (hopboxv1.WorkspaceServiceClient, func(), error)) *cobra.Command {
 	c := &cobra.Command{
-		Use:   "exec <name|id> [--] <command>...",
-		Short: "Run a command in a workspace (non-interactive)",
-		Args:  cobra.MinimumNArgs(2),
+		Use:               "exec <name|id> [--] <command>...",
+		Short:             "Run a command in a workspace (non-interactive)",
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completeWorkspaceArg,
 		RunE: func(_ *cobra.Command, args []string) error {
 			name, command := args[0], args[1:]
 			// With SetInterspersed(false) pflag keeps a literal "--" separator;
@@ -28,58 +29,10 @@ func newExecCmd(dial func() (hopboxv1.WorkspaceServiceClient, func(), error)) *c
 			if len(command) == 0 {
 				return fmt.Errorf("a command is required")
 			}
-			client, closer, err := dial()
+			code, err := runExec(dial, name, command)
 			if err != nil {
 				return err
 			}
-			defer closer()
-
-			stream, err := client.Exec(context.Background())
-			if err != nil {
-				return err
-			}
-			if err := stream.Send(&hopboxv1.ExecClientMsg{Msg: &hopboxv1.ExecClientMsg_Open{
-				Open: &hopboxv1.ExecOpen{NameOrId: name, Cmd: command},
-			}}); err != nil {
-				return err
-			}
-			// stdin pump: forward os.Stdin until EOF, then half-close. Commands
-			// that don't read stdin simply ignore it; this goroutine is abandoned
-			// when the command exits and the program returns.
-			go func() {
-				buf := make([]byte, 32*1024)
-				for {
-					n, rerr := os.Stdin.Read(buf)
-					if n > 0 {
-						_ = stream.Send(&hopboxv1.ExecClientMsg{Msg: &hopboxv1.ExecClientMsg_Stdin{
-							Stdin: append([]byte(nil), buf[:n]...),
-						}})
-					}
-					if rerr != nil {
-						_ = stream.CloseSend()
-						return
-					}
-				}
-			}()
-			code := 0
-			for {
-				msg, rerr := stream.Recv()
-				if rerr == io.EOF {
-					break
-				}
-				if rerr != nil {
-					return rerr
-				}
-				if d := msg.GetStdout(); d != nil {
-					_, _ = os.Stdout.Write(d)
-				}
-				if d := msg.GetStderr(); d != nil {
-					_, _ = os.Stderr.Write(d)
-				}
-				if _, ok := msg.Msg.(*hopboxv1.ExecServerMsg_ExitCode); ok {
-					code = int(msg.GetExitCode())
-				}
-			}
 			if code != 0 {
 				os.Exit(code)
 			}
@@ -91,3 +44,71 @@ func newExecCmd(dial func() (hopboxv1.WorkspaceServiceClient, func(), error)) *c
 	c.Flags().SetInterspersed(false)
 	return c
 }
+
+// runExec runs command (argv) in workspace name over the Exec stream: local
+// stdin is forwarded, stdout/stderr are streamed as they arrive, and the remote
+// exit code is returned.
+func runExec(dial func() (hopboxv1.WorkspaceServiceClient, func(), error), name string, command []string) (int, error) {
+	client, closer, err := dial()
+	if err != nil {
+		return 0, err
+	}
+	defer closer()
+	return execStream(client, name, command, os.Stdin, os.Stdout, os.Stderr)
+}
+
+// execStream drives one Exec stream: stdin (nil for none) is forwarded until
+// EOF, output is copied to stdout/stderr, and the remote exit code is returned.
+func execStream(client hopboxv1.WorkspaceServiceClient, name string, command []string, stdin io.Reader, stdout, stderr io.Writer) (int, error) {
+	stream, err := client.Exec(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	if err := stream.Send(&hopboxv1.ExecClientMsg{Msg: &hopboxv1.ExecClientMsg_Open{
+		Open: &hopboxv1.ExecOpen{NameOrId: name, Cmd: command},
+	}}); err != nil {
+		return 0, err
+	}
+	// stdin pump: forward stdin until EOF, then half-close. Commands
+	// that don't read stdin simply ignore it; this goroutine is abandoned
+	// when the command exits and the program returns.
+	if stdin == nil {
+		_ = stream.CloseSend()
+	} else {
+		go func() {
+			buf := make([]byte, 32*1024)
+			for {
+				n, rerr := stdin.Read(buf)
+				if n > 0 {
+					_ = stream.Send(&hopboxv1.ExecClientMsg{Msg: &hopboxv1.ExecClientMsg_Stdin{
+						Stdin: append([]byte(nil), buf[:n]...),
+					}})
+				}
+				if rerr != nil {
+					_ = stream.CloseSend()
+					return
+				}
+			}
+		}()
+	}
+	code := 0
+	for {
+		msg, rerr := stream.Recv()
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return 0, rerr
+		}
+		if d := msg.GetStdout(); d != nil {
+			_, _ = stdout.Write(d)
+		}
+		if d := msg.GetStderr(); d != nil {
+			_, _ = stderr.Write(d)
+		}
+		if _, ok := msg.Msg.(*hopboxv1.ExecServerMsg_ExitCode); ok {
+			code = int(msg.GetExitCode())
+		}
+	}
+	return code, nil
+}
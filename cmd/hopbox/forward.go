@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	hopboxv1 "github.com/hopboxdev/hopbox/gen/hopbox/v1"
+)
+
+// newForwardCmd forwards a local TCP port to any port inside a workspace — a
+// debug server on 9229, a database, anything not worth exposing through ingress.
+// It runs in the foreground until interrupted; every accepted local connection
+// gets its own Forward stream over the control plane.
+func newForwardCmd(dial func() (hopboxv1.WorkspaceServiceClient, func(), error)) *cobra.Command {
+	var (
+		local int
+		bind  string
+	)
+	c := &cobra.Command{
+		Use:               "forward <name|id> <remote-port>",
+		Short:             "Forward a local port to a port inside a workspace",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeWorkspaceArg,
+		SilenceUsage:      true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			remote, err := strconv.ParseUint(args[1], 10, 16)
+			if err != nil || remote == 0 {
+				return fmt.Errorf("remote port %q: want 1-65535", args[1])
+			}
+			if local < 0 || local > 65535 {
+				return fmt.Errorf("--local %d: want 0-65535", local)
+			}
+			if local == 0 {
+				local = int(remote)
+			}
+			client, closer, err := dial()
+			if err != nil {
+				return err
+			}
+			defer closer()
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			// Fail fast on a bad name rather than on the first connection.
+			w, err := client.GetWorkspace(ctx, &hopboxv1.GetWorkspaceRequest{NameOrId: args[0]})
+			if err != nil {
+				return err
+			}
+			return serveForward(ctx, client, w, uint32(remote), bind, local)
+		},
+	}
+	c.Flags().IntVar(&local, "local", 0, "local port to listen on (default: same as the remote port)")
+	c.Flags().StringVar(&bind, "bind", "127.0.0.1", "local address to listen on")
+	return c
+}
+
+// serveForward listens on bind:local and forwards every accepted connection to
+// port inside w, until ctx is cancelled.
+func serveForward(ctx context.Context, client hopboxv1.WorkspaceServiceClient, w *hopboxv1.Workspace, port uint32, bind string, local int) error {
+	lis, err := net.Listen("tcp", net.JoinHostPort(bind, strconv.Itoa(local)))
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		_ = lis.Close()
+	}()
+	if jsonOut {
+		// One line once listening, so a script can read the (possibly picked)
+		// local address and then connect.
+		if err := printJSON(map[string]any{"local": lis.Addr().String(), "workspace": w.Name, "remote_port": port}); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(os.Stderr, "forwarding %s -> %s:%d (Ctrl-C to stop)\n", lis.Addr(), w.Name, port)
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go func() {
+			if err := forwardConn(ctx, client, w.Id, port, conn); err != nil {
+				fmt.Fprintf(os.Stderr, "forward %s: %v\n", conn.RemoteAddr(), err)
+			}
+		}()
+	}
+}
+
+// forwardConn bridges one accepted local connection to port inside workspace id.
+func forwardConn(ctx context.Context, client hopboxv1.WorkspaceServiceClient, id string, port uint32, conn net.Conn) error {
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := client.Forward(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&hopboxv1.ForwardClientMsg{
+		Msg: &hopboxv1.ForwardClientMsg_Open{Open: &hopboxv1.ForwardOpen{NameOrId: id, Port: port}},
+	}); err != nil {
+		return err
+	}
+
+	// local -> control plane; EOF half-closes the stream.
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, rerr := conn.Read(buf)
+			if n > 0 {
+				if serr := stream.Send(&hopboxv1.ForwardClientMsg{
+					Msg: &hopboxv1.ForwardClientMsg_Data{Data: append([]byte(nil), buf[:n]...)},
+				}); serr != nil {
+					return
+				}
+			}
+			if rerr != nil {
+				_ = stream.CloseSend()
+				return
+			}
+		}
+	}()
+	// control plane -> local, until the workspace side closes.
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if d := msg.GetData(); len(d) > 0 {
+			if _, err := conn.Write(d); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// hintsFor maps a failed command's error to concrete next steps, so a bare
+// "connection refused" or "agent not connected" comes with something to try.
+// Matching is on the gRPC code first, then on the stable wording of hopboxd's
+// error messages (internal/api). Returns nil when there is nothing useful to add.
+func hintsFor(err error) []string {
+	if errors.Is(err, exec.ErrNotFound) {
+		return []string{"install the OpenSSH client (ssh, scp) and make sure it is on $PATH"}
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		if strings.Contains(err.Error(), "config.json") {
+			return []string{"fix or remove ~/.hopbox/config.json (hopbox config list shows what it holds)"}
+		}
+		return nil
+	}
+	msg := st.Message()
+	switch st.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		h := []string{
+			fmt.Sprintf("check that hopboxd is running and reachable at %s", apiAddr),
+			"point the CLI elsewhere: hopbox --addr host:port …, or hopbox config set addr host:port",
+		}
+		if contextName != "" {
+			h[1] = fmt.Sprintf("the address comes from context %q: hopbox context show", contextName)
+		}
+		if apiTLS || apiTLSCA != "" {
+			if strings.Contains(msg, "certificate") {
+				h = append(h, "the server's certificate did not verify: pass its CA with --tls-ca <file> (or hopbox config set tls-ca <file>)")
+			}
+		} else {
+			h = append(h, "if the server serves TLS (--api-tls-cert), add --tls (or hopbox config set tls true)")
+		}
+		return append(h, "for a server with a private API, tunnel it: ssh -L 7700:127.0.0.1:7700 <server>")
+	case codes.Unauthenticated:
+		if tokenFromEnv() {
+			return []string{"the token comes from $HOPBOX_TOKEN: check it is current (tokens in hopboxd's --users file can expire), or unset it"}
+		}
+		if contextName != "" {
+			return []string{fmt.Sprintf("set a valid token for context %q: hopbox context set %s --token - (reads it from stdin)", contextName, contextName)}
+		}
+		return []string{"log in with a valid api token: hopbox login --token - (prompts for it)"}
+	case codes.PermissionDenied:
+		return []string{"your identity lacks access to this; ask a server admin, or check hopbox login / hopbox context show"}
+	case codes.NotFound:
+		if strings.HasPrefix(msg, "workspace ") {
+			return []string{"list your workspaces: hopbox ls"}
+		}
+	case codes.FailedPrecondition:
+		switch {
+		case strings.Contains(msg, "agent rejected"):
+			return []string{
+				"the workspace runs an agent from a different hopbox version, which hopboxd refuses (see: hopbox status <name>)",
+				"restarting the workspace's container or pod stages the current agent; ask a server admin",
+			}
+		case strings.Contains(msg, "agent not connected"):
+			return []string{
+				"the workspace may still be starting or be suspended; check: hopbox status <name>",
+				"if it stays that way, ask a server admin to check hopboxd's log for the workspace's agent (it logs each connect and rejection)",
+			}
+		case strings.Contains(msg, "ssh certificate login is not enabled"):
+			return []string{"the server trusts an external SSH CA (--ssh-ca-pub); get a certificate from your CA tooling instead of hopbox login"}
+		}
+	}
+	return nil
+}
+
+// printHints writes a "Try:" section for err to w, if there are any hints.
+func printHints(w io.Writer, err error) {
+	hints := hintsFor(err)
+	if len(hints) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Try:")
+	for _, h := range hints {
+		fmt.Fprintf(w, "  - %s\n", h)
+	}
+}
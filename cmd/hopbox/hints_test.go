@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHintsFor(t *testing.T) {
+	t.Setenv("HOPBOX_TOKEN", "")
+	contextName = ""
+	for _, tc := range []struct {
+		err  error
+		want string // substring of the first hint; "" = no hints
+	}{
+		{status.Error(codes.Unavailable, "connection refused"), "hopboxd is running"},
+		{status.Error(codes.Unauthenticated, "invalid api token"), "hopbox login --token"},
+		{status.Error(codes.NotFound, `workspace "web" not found`), "hopbox ls"},
+		{status.Error(codes.FailedPrecondition, `workspace "web" agent not connected (phase=Suspended)`), "hopbox status"},
+		{status.Error(codes.FailedPrecondition, `workspace "web" agent not connected (phase=Running): agent rejected: agent speaks protocol 2, hopboxd supports 1-1; restart the workspace to update its agent`), "different hopbox version"},
+		{fmt.Errorf("ssh: %w", &exec.Error{Name: "ssh", Err: exec.ErrNotFound}), "OpenSSH"},
+		{status.Error(codes.InvalidArgument, "cmd is required"), ""},
+		{errors.New("boom"), ""},
+	} {
+		hints := hintsFor(tc.err)
+		for _, h := range hints {
+			if strings.Contains(h, "hopbox rm") {
+				t.Errorf("%v: hint %q deletes the workspace's home volume", tc.err, h)
+			}
+		}
+		switch {
+		case tc.want == "" && len(hints) != 0:
+			t.Errorf("%v: want no hints, got %q", tc.err, hints)
+		case tc.want != "" && (len(hints) == 0 || !strings.Contains(hints[0], tc.want)):
+			t.Errorf("%v: want a hint containing %q, got %q", tc.err, tc.want, hints)
+		}
+	}
+}
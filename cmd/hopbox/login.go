@@ -1,11 +1,15 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"crypto/ed25519"
 	"crypto/rand"
 	"encoding/pem"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,11 +17,12 @@ import (
 
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/term"
 
 	hopboxv1 "github.com/hopboxdev/hopbox/gen/hopbox/v1"
 )
 
-// hopboxDir is ~/.hopbox, holding the user's SSH identity + issued certificate.
+// hopboxDir is ~/.hopbox, holding the CLI config and the credentials (see credDir).
 func hopboxDir() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -27,8 +32,28 @@ func hopboxDir() (string, error) {
 	return d, os.MkdirAll(d, 0o700)
 }
 
-func identityKeyPath() (string, error) {
+// credDir holds the SSH identity, certificate and principal from `hopbox
+// login`: ~/.hopbox itself, or ~/.hopbox/contexts/<name> with a context in use,
+// so logging in to one server never replaces the credentials for another.
+func credDir() (string, error) {
 	d, err := hopboxDir()
+	if err != nil || activeCtx == nil {
+		return d, err
+	}
+	if err := checkContextName(contextName); err != nil {
+		return "", err
+	}
+	d = filepath.Join(d, "contexts", contextName)
+	return d, os.MkdirAll(d, 0o700)
+}
+
+// tokenFromEnv reports whether readToken sends $HOPBOX_TOKEN.
+func tokenFromEnv() bool {
+	return strings.TrimSpace(os.Getenv("HOPBOX_TOKEN")) != "" && (activeCtx == nil || activeCtx.Token == "")
+}
+
+func identityKeyPath() (string, error) {
+	d, err := credDir()
 	if err != nil {
 		return "", err
 	}
@@ -37,7 +62,7 @@ func identityKeyPath() (string, error) {
 
 // readPrincipal returns the principal recorded by the last `hopbox login`.
 func readPrincipal() string {
-	d, err := hopboxDir()
+	d, err := credDir()
 	if err != nil {
 		return ""
 	}
@@ -45,8 +70,23 @@ func readPrincipal() string {
 	return strings.TrimSpace(string(b))
 }
 
-// readToken returns the saved api token (from `hopbox login --token`), if any.
+// readToken returns the api token to send: the active context's, else
+// $HOPBOX_TOKEN (for CI and other headless use, where nothing should be written
+// to disk), else the one saved by `hopbox login --token`, if any. A context's
+// own token wins, so an exported $HOPBOX_TOKEN never replaces the token of a
+// server picked with --context. The saved token belongs to the default server:
+// with a context in use it is never sent, even when the context has no token of
+// its own.
 func readToken() string {
+	if activeCtx != nil && activeCtx.Token != "" {
+		return activeCtx.Token
+	}
+	if tokenFromEnv() {
+		return strings.TrimSpace(os.Getenv("HOPBOX_TOKEN"))
+	}
+	if activeCtx != nil {
+		return ""
+	}
 	d, err := hopboxDir()
 	if err != nil {
 		return ""
@@ -55,6 +95,38 @@ func readToken() string {
 	return strings.TrimSpace(string(b))
 }
 
+// tokenFlag resolves a --token value. "-" reads the token from stdin instead —
+// prompting without echo on a terminal — so it stays out of ps and shell
+// history: `hopbox context set ci --token - <<<"$TOKEN"`.
+func tokenFlag(v string) (string, error) {
+	if v != "-" {
+		return v, nil
+	}
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, "api token: ")
+		b, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		return tokenFrom(bytes.NewReader(b))
+	}
+	return tokenFrom(os.Stdin)
+}
+
+// tokenFrom reads a token from the first line of r.
+func tokenFrom(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(io.LimitReader(r, 64<<10)).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("--token -: %w", err)
+	}
+	tok := strings.TrimSpace(line)
+	if tok == "" {
+		return "", errors.New("--token -: no token on stdin")
+	}
+	return tok, nil
+}
+
 // newLoginCmd ensures a local SSH key exists and exchanges it for a short-lived
 // certificate signed by the server's CA — the credential `ssh`/VS Code present.
 func newLoginCmd(dial func() (hopboxv1.WorkspaceServiceClient, func(), error)) *cobra.Command {
@@ -64,9 +136,18 @@ func newLoginCmd(dial func() (hopboxv1.WorkspaceServiceClient, func(), error)) *
 		Short: "Authenticate and fetch a short-lived SSH certificate",
 		Args:  cobra.NoArgs,
 		RunE: func(_ *cobra.Command, _ []string) error {
+			token, err := tokenFlag(token)
+			if err != nil {
+				return err
+			}
 			// --token saves the api token first, so the cert request below (and all
 			// later calls) authenticate as this user on multi-user servers.
-			if token != "" {
+			if token != "" && activeCtx != nil {
+				activeCtx.Token = token
+				if err := saveConfig(cfg); err != nil {
+					return err
+				}
+			} else if token != "" {
 				d, err := hopboxDir()
 				if err != nil {
 					return err
@@ -105,7 +186,7 @@ func newLoginCmd(dial func() (hopboxv1.WorkspaceServiceClient, func(), error)) *
 			if err := os.WriteFile(keyPath+"-cert.pub", []byte(resp.Certificate), 0o644); err != nil {
 				return err
 			}
-			d, _ := hopboxDir()
+			d, _ := credDir()
 			_ = os.WriteFile(filepath.Join(d, "principal"), []byte(resp.Principal), 0o600)
 
 			fmt.Printf("logged in as %q — certificate valid until %s\n",
@@ -114,7 +195,7 @@ func newLoginCmd(dial func() (hopboxv1.WorkspaceServiceClient, func(), error)) *
 			return nil
 		},
 	}
-	c.Flags().StringVar(&token, "token", "", "api token for multi-user servers (saved to ~/.hopbox/token)")
+	c.Flags().StringVar(&token, "token", "", "api token for multi-user servers (saved to the active context, else ~/.hopbox/token); - reads it from stdin")
 	return c
 }
 
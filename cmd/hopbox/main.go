@@ -3,6 +3,8 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
 	hopboxv1 "github.com/hopboxdev/hopbox/gen/hopbox/v1"
@@ -29,7 +32,11 @@ func parseExpose(s string) (*hopboxv1.IngressPort, error) {
 	return &hopboxv1.IngressPort{Name: name, Port: int32(port)}, nil
 }
 
-var apiAddr string
+var (
+	apiAddr  string
+	apiTLS   bool   // --tls: dial hopboxd over TLS, verified against the system roots
+	apiTLSCA string // --tls-ca: verify against this CA bundle instead (implies TLS)
+)
 
 // tokenCreds sends the saved api token on every call so hopboxd can authenticate
 // the caller. Allowed over insecure transport (localhost / self-hosted).
@@ -40,8 +47,23 @@ func (t tokenCreds) GetRequestMetadata(context.Context, ...string) (map[string]s
 }
 func (tokenCreds) RequireTransportSecurity() bool { return false }
 
+// transportCreds picks plaintext or TLS for the API connection.
+func transportCreds() (credentials.TransportCredentials, error) {
+	switch {
+	case apiTLSCA != "":
+		return credentials.NewClientTLSFromFile(apiTLSCA, "")
+	case apiTLS:
+		return credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12}), nil
+	}
+	return insecure.NewCredentials(), nil
+}
+
 func dial() (hopboxv1.WorkspaceServiceClient, func(), error) {
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	creds, err := transportCreds()
+	if err != nil {
+		return nil, nil, fmt.Errorf("tls: %w", err)
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
 	if tok := readToken(); tok != "" {
 		opts = append(opts, grpc.WithPerRPCCredentials(tokenCreds{tok}))
 	}
@@ -53,12 +75,43 @@ func dial() (hopboxv1.WorkspaceServiceClient, func(), error) {
 }
 
 func main() {
-	root := &cobra.Command{Use: "hopbox", Short: "Hopbox dev-environment CLI"}
+	root := &cobra.Command{
+		Use:   "hopbox",
+		Short: "Hopbox dev-environment CLI",
+		// Fill in defaults from ~/.hopbox/config.json for flags not given.
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := checkJSON(cmd); err != nil {
+				return err
+			}
+			err := applyConfig(cmd.Flags())
+			if errors.Is(err, errUnknownContext) && managesConfig(cmd) {
+				return nil
+			}
+			return err
+		},
+	}
 	root.PersistentFlags().StringVar(&apiAddr, "addr", "localhost:7700", "hopboxd API address")
+	root.PersistentFlags().BoolVar(&apiTLS, "tls", false, "dial hopboxd over TLS")
+	root.PersistentFlags().StringVar(&apiTLSCA, "tls-ca", "", "PEM CA bundle to verify hopboxd's certificate (implies --tls)")
+	root.PersistentFlags().StringVar(&contextName, "context", os.Getenv("HOPBOX_CONTEXT"), "named context to use (default: the current one; env HOPBOX_CONTEXT)")
+	root.PersistentFlags().BoolVar(&jsonOut, "json", false, "print data as JSON (ls, status, create, rm, wait, ports, forward, plugin ls, context, config); other commands refuse it")
+	_ = root.RegisterFlagCompletionFunc("context", completeContextFlag)
 
-	root.AddCommand(newCreateCmd(), newListCmd(), newRmCmd(), newShellCmd(dial), newExecCmd(dial), newProxyCmd(dial), newLoginCmd(dial), newSSHConfigCmd(), newSSHCmd())
+	root.AddCommand(newCreateCmd(), newListCmd(), newRmCmd(), newStatusCmd(), newWaitCmd(), newShellCmd(dial), newExecCmd(dial), newProxyCmd(dial), newForwardCmd(dial), newPortsCmd(dial), newLoginCmd(dial), newSSHConfigCmd(), newSSHCmd(), newCpCmd(), newPluginCmd(), newConfigCmd(), newContextCmd())
+
+	// Unknown subcommands run a hopbox-<name> plugin from $PATH, if one exists.
+	root.InitDefaultHelpCmd()
+	root.InitDefaultCompletionCmd()
+	if code, err := runPlugin(root, os.Args[1:]); err != errNoPlugin {
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			os.Exit(1)
+		}
+		os.Exit(code)
+	}
 	if err := root.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, "error:", err)
+		printHints(os.Stderr, err)
 		os.Exit(1)
 	}
 }
@@ -66,12 +119,22 @@ func main() {
 func newCreateCmd() *cobra.Command {
 	var image string
 	var mem int64
+	var cpus float64
 	var expose []string
 	c := &cobra.Command{
 		Use:   "create <name>",
 		Short: "Create a workspace",
 		Args:  cobra.ExactArgs(1),
-		RunE: func(_ *cobra.Command, args []string) error {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("image") && cfg.Image != "" {
+				image = cfg.Image
+			}
+			if !cmd.Flags().Changed("mem-mb") && cfg.MemMB != 0 {
+				mem = cfg.MemMB
+			}
+			if cpus < 0 {
+				return fmt.Errorf("--cpus %v: must not be negative", cpus)
+			}
 			var ingress []*hopboxv1.IngressPort
 			for _, e := range expose {
 				ip, err := parseExpose(e)
@@ -86,17 +149,25 @@ func newCreateCmd() *cobra.Command {
 			}
 			defer closer()
 			w, err := client.CreateWorkspace(context.Background(), &hopboxv1.CreateWorkspaceRequest{
-				Name: args[0], ImageRef: image, MemMb: mem, Ingress: ingress,
+				Name: args[0], ImageRef: image, MemMb: mem, CpuMillis: int64(cpus * 1000), Ingress: ingress,
 			})
 			if err != nil {
 				return err
 			}
+			if jsonOut {
+				b, err := workspaceJSON(w)
+				if err != nil {
+					return err
+				}
+				return printJSON(b)
+			}
 			fmt.Printf("created %s (%s) phase=%s\n", w.Name, w.Id, w.Phase)
 			return nil
 		},
 	}
 	c.Flags().StringVar(&image, "image", "ubuntu:24.04", "container image")
 	c.Flags().Int64Var(&mem, "mem-mb", 0, "memory limit in MB (0=unlimited)")
+	c.Flags().Float64Var(&cpus, "cpus", 0, "CPU limit in cores, e.g. 1.5 (0=unlimited)")
 	c.Flags().StringArrayVar(&expose, "expose", nil, "expose a workspace port at the gateway: name:port (repeatable)")
 	return c
 }
@@ -115,6 +186,13 @@ func newListCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if jsonOut {
+				ws, err := workspacesJSON(resp.Workspaces)
+				if err != nil {
+					return err
+				}
+				return printJSON(ws)
+			}
 			tw := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
 			fmt.Fprintln(tw, "NAME\tPHASE\tAGENT\tIMAGE\tENDPOINTS\tID")
 			for _, w := range resp.Workspaces {
@@ -135,9 +213,10 @@ func newListCmd() *cobra.Command {
 
 func newRmCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "rm <name|id>",
-		Short: "Destroy a workspace",
-		Args:  cobra.ExactArgs(1),
+		Use:               "rm <name|id>",
+		Short:             "Destroy a workspace",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeWorkspaceArg,
 		RunE: func(_ *cobra.Command, args []string) error {
 			client, closer, err := dial()
 			if err != nil {
@@ -147,6 +226,9 @@ func newRmCmd() *cobra.Command {
 			if _, err := client.DeleteWorkspace(context.Background(), &hopboxv1.DeleteWorkspaceRequest{NameOrId: args[0]}); err != nil {
 				return err
 			}
+			if jsonOut {
+				return printJSON(map[string]any{"name": args[0], "destroying": true})
+			}
 			fmt.Printf("destroying %s\n", args[0])
 			return nil
 		},
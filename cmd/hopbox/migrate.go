@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// configMigrations upgrade ~/.hopbox/config.json one schema version at a time:
+// configMigrations[i] turns version i into i+1. A file without a "version" key
+// is version 0. They work on the raw JSON object, so they can rename or
+// reshape keys the current cliConfig no longer has. Append only.
+var configMigrations = []func(map[string]json.RawMessage) error{
+	migrateConfigV1,
+}
+
+// configVersion is the schema version this CLI reads and writes.
+var configVersion = len(configMigrations)
+
+// migrateConfigV1 brings in the "version" key itself. A version 0 file already
+// has the current keys, so only the version decodeConfig stamps changes — but
+// from here on the file says which CLI wrote it, and an older CLI refuses it.
+func migrateConfigV1(map[string]json.RawMessage) error { return nil }
+
+// decodeConfig parses a config file of any known schema version, running the
+// pending migrations. A file from a newer CLI is an error rather than being
+// half-understood and then overwritten.
+func decodeConfig(b []byte) (*cliConfig, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		raw = map[string]json.RawMessage{}
+	}
+	from := 0
+	if v, ok := raw["version"]; ok {
+		if err := json.Unmarshal(v, &from); err != nil || from < 0 {
+			return nil, fmt.Errorf("version %s: want a non-negative integer", v)
+		}
+	}
+	if from > configVersion {
+		return nil, fmt.Errorf("config version %d is newer than this hopbox understands (%d); upgrade hopbox", from, configVersion)
+	}
+	for v := from; v < configVersion; v++ {
+		if err := configMigrations[v](raw); err != nil {
+			return nil, fmt.Errorf("migrate version %d to %d: %w", v, v+1, err)
+		}
+	}
+	raw["version"] = json.RawMessage(strconv.Itoa(configVersion))
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var c cliConfig
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	c.diskVersion = from
+	return &c, nil
+}
+
+// configBackupPath is where a version-v config file is kept before upgrading.
+func configBackupPath(path string, v int) string {
+	return fmt.Sprintf("%s.v%d.bak", path, v)
+}
+
+// backupConfig copies the file at path aside when it is about to be rewritten
+// in a newer schema. An existing backup of the same version is kept as is.
+func backupConfig(path string, v int) error {
+	if v >= configVersion {
+		return nil
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(configBackupPath(path, v), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// migrateJSON is the result of `config migrate --json`. Without Exists there is
+// no config file; From == To means it is up to date.
+type migrateJSON struct {
+	Path     string     `json:"path"`
+	Exists   bool       `json:"exists"`
+	From     int        `json:"from"`
+	To       int        `json:"to"`
+	Backup   string     `json:"backup,omitempty"`
+	DryRun   bool       `json:"dry_run"`
+	Migrated bool       `json:"migrated"`
+	Config   *cliConfig `json:"config,omitempty"` // the preview, with --dry-run
+}
+
+// newConfigMigrateCmd rewrites the config file in the current schema. Every
+// command already reads old files; this makes the upgrade explicit and lets
+// --dry-run show the result first.
+func newConfigMigrateCmd() *cobra.Command {
+	var dryRun bool
+	c := &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade the config file to the current schema version",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			path, err := configPath()
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+				if jsonOut {
+					return printJSON(migrateJSON{Path: path})
+				}
+				fmt.Printf("no config file at %s\n", path)
+				return nil
+			}
+			c, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			from := c.diskVersion
+			if from == configVersion {
+				if jsonOut {
+					return printJSON(migrateJSON{Path: path, Exists: true, From: from, To: from})
+				}
+				fmt.Printf("%s is up to date (version %d)\n", path, from)
+				return nil
+			}
+			res := migrateJSON{Path: path, Exists: true, From: from, To: configVersion, Backup: configBackupPath(path, from)}
+			if dryRun {
+				// Preview a copy with tokens redacted, as `context ls` does.
+				preview := *c
+				preview.Version, preview.Contexts = configVersion, map[string]*cliContext{}
+				for n, x := range c.Contexts {
+					if x != nil {
+						r := *x
+						r.Token = tokenNote(r.Token)
+						x = &r
+					}
+					preview.Contexts[n] = x
+				}
+				if jsonOut {
+					res.DryRun, res.Config = true, &preview
+					return printJSON(res)
+				}
+				b, err := json.MarshalIndent(&preview, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Printf("would migrate %s from version %d to %d (backup: %s):\n", path, from, configVersion, configBackupPath(path, from))
+				fmt.Printf("%s\n", b)
+				return nil
+			}
+			if err := saveConfig(c); err != nil {
+				return err
+			}
+			if jsonOut {
+				res.Migrated = true
+				return printJSON(res)
+			}
+			fmt.Printf("migrated %s from version %d to %d (backup: %s)\n", path, from, configVersion, configBackupPath(path, from))
+			return nil
+		},
+	}
+	c.Flags().BoolVar(&dryRun, "dry-run", false, "print the upgraded config without writing it")
+	return c
+}
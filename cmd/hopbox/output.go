@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	hopboxv1 "github.com/hopboxdev/hopbox/gen/hopbox/v1"
+)
+
+// jsonOut is the global --json flag: commands that print data emit it as JSON
+// on stdout instead of text tables, for scripts and other tools. Errors and
+// progress notes still go to stderr as text.
+var jsonOut bool
+
+// jsonCommands are the commands with JSON output, by their path below the root.
+// The rest refuse --json rather than print text a script would choke on.
+var jsonCommands = map[string]bool{
+	"ls": true, "status": true, "create": true, "rm": true, "wait": true,
+	"ports": true, "forward": true, "plugin ls": true,
+	"context set": true, "context use": true, "context ls": true, "context show": true, "context rm": true,
+	"config get": true, "config set": true, "config unset": true, "config list": true, "config migrate": true,
+}
+
+// checkJSON fails a --json run of a command without JSON output. Help and
+// cobra's completion commands are left alone.
+func checkJSON(cmd *cobra.Command) error {
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	if !jsonOut || jsonCommands[path] || path == "help" || strings.HasPrefix(path, "completion") || strings.HasPrefix(cmd.Name(), "__") {
+		return nil
+	}
+	return fmt.Errorf("--json: %s has no JSON output", cmd.CommandPath())
+}
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// workspaceJSON encodes w with the proto field names (snake_case, as in
+// hopbox.proto) and every field present, so scripts can rely on the keys.
+// protojson writes 64-bit integers as strings; mem_mb and cpu_millis are
+// sizes a script compares, so they are turned back into JSON numbers.
+func workspaceJSON(w *hopboxv1.Workspace) (json.RawMessage, error) {
+	b, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(w)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	fields := w.ProtoReflect().Descriptor().Fields()
+	for i := range fields.Len() {
+		f := fields.Get(i)
+		switch f.Kind() {
+		case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+			protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		default:
+			continue
+		}
+		var n string
+		if !f.IsList() && json.Unmarshal(m[string(f.Name())], &n) == nil {
+			m[string(f.Name())] = json.RawMessage(n)
+		}
+	}
+	return json.Marshal(m)
+}
+
+// workspacesJSON encodes ws as a JSON array (never null).
+func workspacesJSON(ws []*hopboxv1.Workspace) ([]json.RawMessage, error) {
+	out := make([]json.RawMessage, 0, len(ws))
+	for _, w := range ws {
+		b, err := workspaceJSON(w)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return out, nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	hopboxv1 "github.com/hopboxdev/hopbox/gen/hopbox/v1"
+)
+
+// --json output uses the proto field names and always carries every key, so
+// scripts can index it without checking for absence.
+func TestWorkspaceJSONKeys(t *testing.T) {
+	ws, err := workspacesJSON([]*hopboxv1.Workspace{{Name: "api", Phase: "Running", MemMb: 4096}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var w map[string]any
+	if err := json.Unmarshal(ws[0], &w); err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []string{"name", "phase", "agent_connected", "image_ref", "endpoints"} {
+		if _, ok := w[k]; !ok {
+			t.Errorf("workspace JSON lacks %q: %v", k, w)
+		}
+	}
+	if w["mem_mb"] != 4096.0 || w["cpu_millis"] != 0.0 {
+		t.Errorf("int64 fields should be JSON numbers: mem_mb=%#v cpu_millis=%#v", w["mem_mb"], w["cpu_millis"])
+	}
+	if empty, _ := workspacesJSON(nil); empty == nil {
+		t.Fatal("no workspaces should encode as [], not null")
+	}
+
+	b, err := json.Marshal(portJSON{listener{Port: 8000, Addr: "0.0.0.0", Program: "python3"}, "app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"port":8000,"address":"0.0.0.0","program":"python3","exposed":"app"}`; string(b) != want {
+		t.Fatalf("port JSON = %s, want %s", b, want)
+	}
+}
+
+// Commands without JSON output refuse --json instead of printing text.
+func TestCheckJSON(t *testing.T) {
+	t.Cleanup(func() { jsonOut = false })
+	root := &cobra.Command{Use: "hopbox"}
+	ls, exec := &cobra.Command{Use: "ls"}, &cobra.Command{Use: "exec"}
+	ctx, use := &cobra.Command{Use: "context"}, &cobra.Command{Use: "use"}
+	comp := &cobra.Command{Use: "__complete"}
+	ctx.AddCommand(use)
+	root.AddCommand(ls, exec, ctx, comp)
+
+	jsonOut = false
+	if err := checkJSON(exec); err != nil {
+		t.Fatalf("without --json: %v", err)
+	}
+	jsonOut = true
+	for cmd, ok := range map[*cobra.Command]bool{ls: true, use: true, comp: true, exec: false} {
+		if err := checkJSON(cmd); (err == nil) != ok {
+			t.Errorf("checkJSON(%s) = %v, want ok=%v", cmd.CommandPath(), err, ok)
+		}
+	}
+}
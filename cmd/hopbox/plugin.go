@@ -0,0 +1,231 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// pluginPrefix names external subcommands, git-style: `hopbox deploy-internal`
+// runs the first `hopbox-deploy-internal` executable on $PATH.
+const pluginPrefix = "hopbox-"
+
+// shippedBinaries are the project's own hopbox-* executables. They share the
+// prefix but are daemons/in-box tools, not CLI plugins, so they are never
+// dispatched to or listed.
+var shippedBinaries = map[string]bool{
+	"agent": true, "gw": true, "mcp": true, "provider": true,
+}
+
+// plugin is one discovered hopbox-<name> executable.
+type plugin struct {
+	Name     string
+	Path     string
+	Shadowed bool // a same-named plugin earlier on $PATH wins
+}
+
+// findPlugins scans the directories of pathEnv, in order, for executable
+// hopbox-<name> files. The first hit for a name is the one that runs; later
+// ones are returned marked Shadowed.
+func findPlugins(pathEnv string) []plugin {
+	var out []plugin
+	seen := map[string]bool{}
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir == "" {
+			dir = "."
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name, ok := pluginName(e.Name())
+			if !ok || e.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			out = append(out, plugin{Name: name, Path: path, Shadowed: seen[name]})
+			seen[name] = true
+		}
+	}
+	return out
+}
+
+// pluginName maps a file name to its subcommand name ("hopbox-foo" -> "foo").
+func pluginName(file string) (string, bool) {
+	if runtime.GOOS == "windows" {
+		file = strings.TrimSuffix(file, ".exe")
+	}
+	name, ok := strings.CutPrefix(file, pluginPrefix)
+	if !ok || name == "" || shippedBinaries[name] {
+		return "", false
+	}
+	return name, true
+}
+
+func isExecutable(path string) bool {
+	fi, err := os.Stat(path)
+	if err != nil || fi.IsDir() {
+		return false
+	}
+	return runtime.GOOS == "windows" || fi.Mode()&0o111 != 0
+}
+
+// pluginEnv is the context handed to a plugin: which server the CLI is pointed
+// at, where its credentials live, and how to call back into the CLI itself.
+func pluginEnv() []string {
+	env := append(os.Environ(), "HOPBOX_ADDR="+apiAddr)
+	if d, err := credDir(); err == nil {
+		env = append(env, "HOPBOX_DIR="+d)
+	}
+	if p := readPrincipal(); p != "" {
+		env = append(env, "HOPBOX_PRINCIPAL="+p)
+	}
+	if contextName != "" {
+		env = append(env, "HOPBOX_CONTEXT="+contextName)
+	}
+	if apiTLS || apiTLSCA != "" {
+		env = append(env, "HOPBOX_TLS=1")
+	}
+	if apiTLSCA != "" {
+		env = append(env, "HOPBOX_TLS_CA="+apiTLSCA)
+	}
+	if self, err := os.Executable(); err == nil {
+		env = append(env, "HOPBOX_BIN="+self)
+	}
+	return env
+}
+
+// errNoPlugin means args do not name an external plugin; run the CLI normally.
+var errNoPlugin = errors.New("no plugin")
+
+// runPlugin dispatches args to a hopbox-<name> plugin when the first positional
+// argument is not a built-in command. Global flags before the name (--addr) are
+// parsed so the plugin sees the effective values. Returns the plugin's exit code,
+// or errNoPlugin when the CLI should handle args itself.
+func runPlugin(root *cobra.Command, args []string) (int, error) {
+	i := firstPositional(root, args)
+	if i < 0 {
+		return 0, errNoPlugin
+	}
+	name := args[i]
+	if c, _, err := root.Find([]string{name}); err == nil && c != root {
+		return 0, errNoPlugin // a built-in command (or its alias)
+	}
+	if _, ok := pluginName(pluginPrefix + name); !ok {
+		return 0, errNoPlugin
+	}
+	path, err := exec.LookPath(pluginPrefix + name)
+	if err != nil {
+		return 0, errNoPlugin
+	}
+	if err := root.PersistentFlags().Parse(args[:i]); err != nil {
+		return 0, err
+	}
+	if err := applyConfig(root.PersistentFlags()); err != nil {
+		return 0, err
+	}
+	cmd := exec.Command(path, args[i+1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = pluginEnv()
+	if err := cmd.Run(); err != nil {
+		var ee *exec.ExitError
+		if errors.As(err, &ee) {
+			return ee.ExitCode(), nil
+		}
+		return 0, fmt.Errorf("plugin %s: %w", name, err)
+	}
+	return 0, nil
+}
+
+// firstPositional returns the index of the first non-flag argument, skipping the
+// values of root's persistent flags given as separate words (`--addr host:port`).
+// -1 if there is none, or if a "--" terminator comes first.
+func firstPositional(root *cobra.Command, args []string) int {
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "--" {
+			return -1
+		}
+		if !strings.HasPrefix(a, "-") {
+			return i
+		}
+		if strings.Contains(a, "=") {
+			continue
+		}
+		name := strings.TrimLeft(a, "-")
+		f := root.PersistentFlags().Lookup(name)
+		if f == nil && len(name) == 1 {
+			f = root.PersistentFlags().ShorthandLookup(name)
+		}
+		if f != nil && f.NoOptDefVal == "" {
+			i++ // the flag's value is the next word
+		}
+	}
+	return -1
+}
+
+func newPluginCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "plugin",
+		Short: "Manage external hopbox-<name> subcommands",
+	}
+	c.AddCommand(&cobra.Command{
+		Use:   "ls",
+		Short: "List hopbox-<name> plugins found on $PATH",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			plugins := findPlugins(os.Getenv("PATH"))
+			if len(plugins) == 0 && !jsonOut {
+				fmt.Println("no plugins found (put an executable named hopbox-<name> on $PATH)")
+				return nil
+			}
+			sort.SliceStable(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+			root := cmd.Root()
+			type row struct {
+				Name     string `json:"name"`
+				Path     string `json:"path"`
+				Hidden   bool   `json:"hidden"`   // a built-in command of the same name wins
+				Shadowed bool   `json:"shadowed"` // a same-named plugin earlier on $PATH wins
+			}
+			rows := make([]row, 0, len(plugins))
+			tw := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+			if !jsonOut {
+				fmt.Fprintln(tw, "NAME\tPATH\tNOTE")
+			}
+			for _, p := range plugins {
+				r := row{Name: p.Name, Path: p.Path, Shadowed: p.Shadowed}
+				if c, _, err := root.Find([]string{p.Name}); err == nil && c != root {
+					r.Hidden = true
+				}
+				if jsonOut {
+					rows = append(rows, r)
+					continue
+				}
+				note := "-"
+				if r.Hidden {
+					note = "hidden by built-in command"
+				} else if r.Shadowed {
+					note = "shadowed (earlier on $PATH)"
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", p.Name, p.Path, note)
+			}
+			if jsonOut {
+				return printJSON(rows)
+			}
+			return tw.Flush()
+		},
+	})
+	return c
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func writeExe(t *testing.T, dir, name string, mode os.FileMode) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// findPlugins walks $PATH in order: first hit wins, later ones are shadowed, and
+// non-executables and the project's own hopbox-* binaries are skipped.
+func TestFindPlugins(t *testing.T) {
+	a, b := t.TempDir(), t.TempDir()
+	writeExe(t, a, "hopbox-deploy", 0o755)
+	writeExe(t, a, "hopbox-notes", 0o644) // not executable
+	writeExe(t, a, "hopbox-agent", 0o755) // shipped binary, not a plugin
+	writeExe(t, b, "hopbox-deploy", 0o755)
+	writeExe(t, b, "hopbox-lint", 0o755)
+
+	got := findPlugins(strings.Join([]string{a, b}, string(os.PathListSeparator)))
+	if len(got) != 3 {
+		t.Fatalf("plugins = %+v, want 3", got)
+	}
+	if got[0].Name != "deploy" || got[0].Path != filepath.Join(a, "hopbox-deploy") || got[0].Shadowed {
+		t.Fatalf("first deploy = %+v", got[0])
+	}
+	if got[1].Name != "deploy" || !got[1].Shadowed {
+		t.Fatalf("second deploy should be shadowed: %+v", got[1])
+	}
+	if got[2].Name != "lint" || got[2].Shadowed {
+		t.Fatalf("lint = %+v", got[2])
+	}
+}
+
+// Global flag values given as separate words are not mistaken for the command.
+func TestFirstPositional(t *testing.T) {
+	root := &cobra.Command{Use: "hopbox"}
+	root.PersistentFlags().String("addr", "", "")
+	for _, tc := range []struct {
+		args []string
+		want int
+	}{
+		{[]string{"deploy", "x"}, 0},
+		{[]string{"--addr", "h:1", "deploy"}, 2},
+		{[]string{"--addr=h:1", "deploy"}, 1},
+		{[]string{"--", "deploy"}, -1},
+		{[]string{"--addr", "h:1"}, -1},
+	} {
+		if got := firstPositional(root, tc.args); got != tc.want {
+			t.Errorf("firstPositional(%q) = %d, want %d", tc.args, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	hopboxv1 "github.com/hopboxdev/hopbox/gen/hopbox/v1"
+)
+
+// listenScript dumps, in one round trip, what parseListeners needs: the kernel's
+// TCP socket tables, each process's socket fds, and process names. It relies
+// only on /proc and busybox-level tools, so it works in any image.
+const listenScript = `cat /proc/net/tcp /proc/net/tcp6 2>/dev/null
+echo @@fd
+ls -l /proc/[0-9]*/fd 2>/dev/null
+echo @@comm
+grep -H '' /proc/[0-9]*/comm 2>/dev/null
+true`
+
+// listener is one listening TCP socket inside a workspace.
+type listener struct {
+	Port    int    `json:"port"`
+	Addr    string `json:"address"`
+	Program string `json:"program"` // "" when the owning process is not visible to the agent
+}
+
+// portJSON is one row of `ports --json`.
+type portJSON struct {
+	listener
+	Exposed string `json:"exposed"` // ingress name, "" if not exposed
+}
+
+// parseListeners turns listenScript's output into the listening sockets, sorted
+// by port then address.
+func parseListeners(out string) []listener {
+	var (
+		section = "tcp"
+		socks   = map[string]listener{} // inode -> socket
+		owner   = map[string]string{}   // inode -> pid
+		comm    = map[string]string{}   // pid -> program
+		pid     string
+	)
+	sc := bufio.NewScanner(strings.NewReader(out))
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		switch line {
+		case "@@fd", "@@comm":
+			section = line[2:]
+			continue
+		}
+		switch section {
+		case "tcp":
+			// sl local_address rem_address st tx:rx tr:when retrnsmt uid timeout inode
+			f := strings.Fields(line)
+			if len(f) < 10 || f[3] != "0A" { // 0A = TCP_LISTEN
+				continue
+			}
+			addr, port, ok := parseHexAddr(f[1])
+			if !ok {
+				continue
+			}
+			socks[f[9]] = listener{Port: port, Addr: addr}
+		case "fd":
+			if strings.HasPrefix(line, "/proc/") && strings.HasSuffix(line, "/fd:") {
+				pid = strings.TrimSuffix(strings.TrimPrefix(line, "/proc/"), "/fd:")
+				continue
+			}
+			if _, target, ok := strings.Cut(line, "-> socket:["); ok {
+				owner[strings.TrimSuffix(target, "]")] = pid
+			}
+		case "comm":
+			// /proc/<pid>/comm:<name>
+			path, name, ok := strings.Cut(line, ":")
+			if !ok {
+				continue
+			}
+			p := strings.TrimSuffix(strings.TrimPrefix(path, "/proc/"), "/comm")
+			comm[p] = name
+		}
+	}
+	seen := map[listener]bool{}
+	found := make([]listener, 0, len(socks))
+	for inode, l := range socks {
+		if p, ok := owner[inode]; ok {
+			l.Program = comm[p]
+		}
+		if !seen[l] {
+			seen[l] = true
+			found = append(found, l)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].Port != found[j].Port {
+			return found[i].Port < found[j].Port
+		}
+		return found[i].Addr < found[j].Addr
+	})
+	return found
+}
+
+// parseHexAddr decodes a /proc/net/tcp{,6} "ADDR:PORT" field. The address is
+// stored as native-endian (little-endian on every platform we run) 32-bit words;
+// the port is big-endian.
+func parseHexAddr(s string) (string, int, bool) {
+	a, p, ok := strings.Cut(s, ":")
+	if !ok {
+		return "", 0, false
+	}
+	port, err := strconv.ParseUint(p, 16, 16)
+	if err != nil {
+		return "", 0, false
+	}
+	b, err := hex.DecodeString(a)
+	if err != nil || (len(b) != 4 && len(b) != 16) {
+		return "", 0, false
+	}
+	for i := 0; i < len(b); i += 4 {
+		b[i], b[i+1], b[i+2], b[i+3] = b[i+3], b[i+2], b[i+1], b[i]
+	}
+	return net.IP(b).String(), int(port), true
+}
+
+// newPortsCmd lists the TCP ports listening inside a workspace, marking those
+// exposed through ingress, and can forward one locally (like `hopbox forward`).
+func newPortsCmd(dial func() (hopboxv1.WorkspaceServiceClient, func(), error)) *cobra.Command {
+	var fwd int
+	c := &cobra.Command{
+		Use:               "ports <name|id>",
+		Short:             "List listening ports inside a workspace",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeWorkspaceArg,
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Check flags before touching the server, so a typo fails fast rather
+			// than after the listing has been printed.
+			if cmd.Flags().Changed("forward") && (fwd < 1 || fwd > 65535) {
+				return fmt.Errorf("--forward %d: want 1-65535", fwd)
+			}
+			client, closer, err := dial()
+			if err != nil {
+				return err
+			}
+			defer closer()
+			w, err := client.GetWorkspace(context.Background(), &hopboxv1.GetWorkspaceRequest{NameOrId: args[0]})
+			if err != nil {
+				return err
+			}
+			var stdout, stderr bytes.Buffer
+			code, err := execStream(client, w.Id, []string{"/bin/sh", "-c", listenScript}, nil, &stdout, &stderr)
+			if err != nil {
+				return err
+			}
+			if code != 0 {
+				return fmt.Errorf("list ports: exit %d: %s", code, strings.TrimSpace(stderr.String()))
+			}
+			exposed := map[int]string{}
+			for _, e := range w.Endpoints {
+				exposed[int(e.Port)] = e.Name
+			}
+			found := parseListeners(stdout.String())
+			if jsonOut {
+				out := make([]portJSON, 0, len(found))
+				for _, l := range found {
+					out = append(out, portJSON{l, exposed[l.Port]})
+				}
+				if err := printJSON(out); err != nil {
+					return err
+				}
+			} else {
+				tw := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+				fmt.Fprintln(tw, "PORT\tADDRESS\tPROGRAM\tEXPOSED")
+				for _, l := range found {
+					fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", l.Port, l.Addr, orDash(l.Program), orDash(exposed[l.Port]))
+				}
+				if err := tw.Flush(); err != nil {
+					return err
+				}
+			}
+			if fwd == 0 {
+				return nil
+			}
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return serveForward(ctx, client, w, uint32(fwd), "127.0.0.1", fwd)
+		},
+	}
+	c.Flags().IntVar(&fwd, "forward", 0, "after listing, forward this port to the same local port (until Ctrl-C)")
+	return c
+}
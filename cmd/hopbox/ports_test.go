@@ -0,0 +1,54 @@
+package main
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	hopboxv1 "github.com/hopboxdev/hopbox/gen/hopbox/v1"
+)
+
+func TestParseListeners(t *testing.T) {
+	out := `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 0100007F:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 111 1 0000000000000000 100 0 0 10 0
+   1: 00000000:0016 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 222 1 0000000000000000 100 0 0 10 0
+   2: 0100007F:1F90 0100007F:C350 01 00000000:00000000 00:00000000 00000000  1000        0 333 1 0000000000000000 20 4 30 10 -1
+  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000000000000000000000000000:2405 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 444 1 0000000000000000 100 0 0 10 0
+@@fd
+/proc/1/fd:
+total 0
+lrwx------ 1 root root 64 Oct 16 10:00 3 -> socket:[222]
+/proc/42/fd:
+lr-x------ 1 dev dev 64 Oct 16 10:00 0 -> /dev/null
+lrwx------ 1 dev dev 64 Oct 16 10:00 7 -> socket:[111]
+@@comm
+/proc/1/comm:hopbox-agent
+/proc/42/comm:node
+`
+	want := []listener{
+		{Port: 22, Addr: "0.0.0.0", Program: "hopbox-agent"},
+		{Port: 8080, Addr: "127.0.0.1", Program: "node"},
+		{Port: 9221, Addr: "::"},
+	}
+	if got := parseListeners(out); !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseListeners =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+// A bad --forward is refused before the server is asked for anything.
+func TestPortsValidatesForwardFirst(t *testing.T) {
+	for _, arg := range []string{"--forward=0", "--forward=-1", "--forward=70000"} {
+		c := newPortsCmd(func() (hopboxv1.WorkspaceServiceClient, func(), error) {
+			t.Fatalf("%s: dialed the server before validating flags", arg)
+			return nil, nil, nil
+		})
+		c.SetArgs([]string{"web", arg})
+		c.SetOut(io.Discard)
+		c.SetErr(io.Discard)
+		if err := c.Execute(); err == nil || !strings.Contains(err.Error(), "want 1-65535") {
+			t.Fatalf("%s: err = %v", arg, err)
+		}
+	}
+}
@@ -22,11 +22,12 @@ import (
 // with no public port and no extra steps.
 func newProxyCmd(dial func() (hopboxv1.WorkspaceServiceClient, func(), error)) *cobra.Command {
 	c := &cobra.Command{
-		Use:           "proxy <name|id>",
-		Short:         "Stdio SSH transport to a workspace (use as an SSH ProxyCommand)",
-		Args:          cobra.ExactArgs(1),
-		SilenceUsage:  true,
-		SilenceErrors: false,
+		Use:               "proxy <name|id>",
+		Short:             "Stdio SSH transport to a workspace (use as an SSH ProxyCommand)",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeWorkspaceArg,
+		SilenceUsage:      true,
+		SilenceErrors:     false,
 		RunE: func(_ *cobra.Command, args []string) error {
 			client, closer, err := dial()
 			if err != nil {
@@ -58,12 +58,45 @@ func pump(stream shellStream, stdin io.Reader, stdout io.Writer) int {
 	return <-exit
 }
 
+// loginShellScript runs "$1" through the user's login shell with -lc, so the
+// login profile that sets PATH, version managers and the like is read. The
+// shell is the first usable one of passwd's, $SHELL, bash and sh; a passwd
+// entry of nologin or false (common for root in images) is skipped rather than
+// run, since it would refuse the command. This differs from an interactive
+// `hopbox shell`, which is a plain, non-login /bin/bash reading ~/.bashrc (see
+// buildCommand in hopbox-agent).
+const loginShellScript = `for s in "$(getent passwd "$(id -u)" 2>/dev/null | cut -d: -f7)" "${SHELL:-}" /bin/bash /bin/sh; do
+	case $s in ''|*nologin|*false) continue ;; esac
+	[ -x "$s" ] && break
+done
+exec "$s" -lc "$1"`
+
+// loginShellArgv is the exec argv for `hopbox shell -c command`.
+func loginShellArgv(command string) []string {
+	return []string{"/bin/sh", "-c", loginShellScript, "hopbox", command}
+}
+
 func newShellCmd(dial func() (hopboxv1.WorkspaceServiceClient, func(), error)) *cobra.Command {
-	return &cobra.Command{
-		Use:   "shell <name|id>",
-		Short: "Open an interactive shell in a workspace",
-		Args:  cobra.ExactArgs(1),
+	var command string
+	c := &cobra.Command{
+		Use:               "shell <name|id> [-c <command>]",
+		Short:             "Open an interactive shell in a workspace, or run one command with -c",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeWorkspaceArg,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// -c: run one command through the user's login shell, non-interactively,
+			// in the same place an interactive shell opens (the workspace home), and
+			// exit with its exit code. No pty, so output pipes cleanly in scripts.
+			if cmd.Flags().Changed("command") {
+				code, err := runExec(dial, args[0], loginShellArgv(command))
+				if err != nil {
+					return err
+				}
+				if code != 0 {
+					os.Exit(code)
+				}
+				return nil
+			}
 			client, closer, err := dial()
 			if err != nil {
 				return err
@@ -97,4 +130,6 @@ func newShellCmd(dial func() (hopboxv1.WorkspaceServiceClient, func(), error)) *
 			return nil
 		},
 	}
+	c.Flags().StringVarP(&command, "command", "c", "", "run this command through your login shell (-lc, so your profile is read) instead of an interactive shell; exits with its code")
+	return c
 }
@@ -2,7 +2,12 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	hopboxv1 "github.com/hopboxdev/hopbox/gen/hopbox/v1"
@@ -61,3 +66,34 @@ func TestPumpSendsStdinToServer(t *testing.T) {
 		t.Fatalf("sent=%q", joined)
 	}
 }
+
+// shell -c goes through a login shell, so what the profile sets up is there. A
+// fake getent pins the passwd shell, so the host's own entry does not matter;
+// a nologin or false entry falls through to $SHELL.
+func TestLoginShellArgvReadsProfile(t *testing.T) {
+	home, bin := t.TempDir(), t.TempDir()
+	if err := os.WriteFile(filepath.Join(home, ".profile"), []byte("export HOPBOX_PROFILE=read\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct{ passwd, shell string }{
+		{passwd: "/bin/sh", shell: "/nonexistent"},
+		{passwd: "/usr/sbin/nologin", shell: "/bin/sh"},
+		{passwd: "/bin/false", shell: "/bin/sh"},
+	} {
+		getent := "#!/bin/sh\necho 'u:x:0:0::/home/u:" + tc.passwd + "'\n"
+		if err := os.WriteFile(filepath.Join(bin, "getent"), []byte(getent), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		argv := loginShellArgv(`printf '%s %s %s' "$0" "$HOPBOX_PROFILE" 'quoted arg'; exit 3`)
+		c := exec.Command(argv[0], argv[1:]...)
+		c.Env = []string{"PATH=" + bin + ":/usr/bin:/bin", "HOME=" + home, "SHELL=" + tc.shell}
+		out, err := c.Output()
+		var ee *exec.ExitError
+		if !errors.As(err, &ee) || ee.ExitCode() != 3 {
+			t.Fatalf("passwd %s: exit: %v (output %q)", tc.passwd, err, out)
+		}
+		if got := strings.TrimSpace(string(out)); got != "/bin/sh read quoted arg" {
+			t.Fatalf("passwd %s: got %q", tc.passwd, got)
+		}
+	}
+}
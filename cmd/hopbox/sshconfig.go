@@ -18,22 +18,17 @@ import (
 func newSSHConfigCmd() *cobra.Command {
 	var alias, user string
 	c := &cobra.Command{
-		Use:   "ssh-config <name|id>",
-		Short: "Write an SSH config entry for a workspace (ssh / VS Code Remote-SSH)",
-		Args:  cobra.ExactArgs(1),
+		Use:               "ssh-config <name|id>",
+		Short:             "Write an SSH config entry for a workspace (ssh / VS Code Remote-SSH)",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeWorkspaceArg,
 		RunE: func(_ *cobra.Command, args []string) error {
 			name := args[0]
 			if alias == "" {
 				alias = name
 			}
-			if user == "" { // default to the principal from `hopbox login`
-				if user = readPrincipal(); user == "" {
-					user = "dev"
-				}
-			}
-			self, err := os.Executable()
-			if err != nil || self == "" {
-				self = "hopbox" // fall back to PATH lookup
+			if user == "" { // configured user, else the principal from `hopbox login`
+				user = defaultUser()
 			}
 			idPath, err := identityKeyPath()
 			if err != nil {
@@ -57,10 +52,10 @@ Host %s
     User %s
     IdentityFile %s
     IdentitiesOnly yes
-    ProxyCommand %q proxy %s --addr %s
+    ProxyCommand %s
     StrictHostKeyChecking accept-new
     UserKnownHostsFile ~/.ssh/known_hosts
-`, name, name, alias, user, idPath, self, name, apiAddr)
+`, name, name, alias, user, idPath, proxyCommand(name))
 
 			path := filepath.Join(sshDir, "hopbox", alias+".config")
 			if err := os.WriteFile(path, []byte(block), 0o600); err != nil {
@@ -71,10 +66,44 @@ Host %s
 		},
 	}
 	c.Flags().StringVar(&alias, "alias", "", "SSH host alias (default: the workspace name)")
-	c.Flags().StringVar(&user, "user", "", "remote user (default: your principal from `hopbox login`)")
+	c.Flags().StringVar(&user, "user", "", "remote user (default: config user, else your principal from `hopbox login`)")
 	return c
 }
 
+// proxyCommand is the ssh ProxyCommand that reaches workspace name through
+// `hopbox proxy`, pinned to the server this invocation talks to: its address,
+// TLS settings and the active context (whose token the proxy must send).
+func proxyCommand(name string) string {
+	self, err := os.Executable()
+	if err != nil || self == "" {
+		self = "hopbox" // fall back to PATH lookup
+	}
+	cmd := fmt.Sprintf("%q proxy %s --addr %s", self, name, apiAddr)
+	if contextName != "" {
+		cmd += fmt.Sprintf(" --context %q", contextName)
+	}
+	switch {
+	case apiTLSCA != "":
+		cmd += fmt.Sprintf(" --tls-ca %q", apiTLSCA)
+	case apiTLS:
+		cmd += " --tls"
+	}
+	return cmd
+}
+
+// proxyOpts are the ssh/scp -o options for reaching workspace name (or "%h",
+// which ssh expands to each destination host) without an ssh-config entry.
+func proxyOpts(name string) []string {
+	opts := []string{
+		"-o", "ProxyCommand=" + proxyCommand(name),
+		"-o", "StrictHostKeyChecking=accept-new",
+	}
+	if idPath, _ := identityKeyPath(); idPath != "" {
+		opts = append(opts, "-o", "IdentityFile="+idPath, "-o", "IdentitiesOnly=yes")
+	}
+	return opts
+}
+
 // ensureInclude makes sure ~/.ssh/config pulls in the hopbox entries. OpenSSH
 // requires Include before the first Host block, so we prepend it.
 func ensureInclude(configPath string) error {
@@ -99,6 +128,7 @@ func newSSHCmd() *cobra.Command {
 		Use:                "ssh <name|id> [-- ssh args...]",
 		Short:              "SSH into a workspace (wraps the system ssh)",
 		Args:               cobra.MinimumNArgs(1),
+		ValidArgsFunction:  completeWorkspaceArg,
 		DisableFlagParsing: true,
 		RunE: func(_ *cobra.Command, args []string) error {
 			// crude flag handling so DisableFlagParsing still honours --user.
@@ -113,27 +143,13 @@ func newSSHCmd() *cobra.Command {
 				rest = append(rest, args[i])
 			}
 			if user == "" {
-				if user = readPrincipal(); user == "" {
-					user = "dev"
-				}
+				user = defaultUser()
 			}
 			if len(rest) == 0 {
 				return fmt.Errorf("a workspace name is required")
 			}
 			name, extra := rest[0], rest[1:]
-			self, err := os.Executable()
-			if err != nil || self == "" {
-				self = "hopbox"
-			}
-			idPath, _ := identityKeyPath()
-			sshArgs := []string{
-				"-o", fmt.Sprintf("ProxyCommand=%q proxy %s --addr %s", self, name, apiAddr),
-				"-o", "StrictHostKeyChecking=accept-new",
-			}
-			if idPath != "" {
-				sshArgs = append(sshArgs, "-o", "IdentityFile="+idPath, "-o", "IdentitiesOnly=yes")
-			}
-			sshArgs = append(sshArgs, user+"@"+name)
+			sshArgs := append(proxyOpts(name), user+"@"+name)
 			sshArgs = append(sshArgs, extra...)
 			ssh := exec.Command("ssh", sshArgs...)
 			ssh.Stdin, ssh.Stdout, ssh.Stderr = os.Stdin, os.Stdout, os.Stderr
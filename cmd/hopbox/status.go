@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	hopboxv1 "github.com/hopboxdev/hopbox/gen/hopbox/v1"
+	"github.com/hopboxdev/hopbox/internal/core/box"
+)
+
+// Exit codes for `hopbox status`, stable so scripts, CI and shell prompts can
+// branch on workspace health without parsing text. 1 stays the generic CLI
+// error (server unreachable, workspace not found, bad args).
+const (
+	exitHealthy    = 0
+	exitError      = 1 // the CLI's own error exit, used by `wait` for an unreachable server
+	exitNotRunning = 2 // pending, provisioning, suspended, stopped or being destroyed
+	exitAgentDown  = 3 // running, but its agent is not connected to the control plane
+	exitUnhealthy  = 4 // failed
+)
+
+// workspaceHealth classifies w into a status exit code and a one-word summary.
+func workspaceHealth(w *hopboxv1.Workspace) (int, string) {
+	switch box.Phase(w.Phase) {
+	case box.PhaseRunning:
+		if !w.AgentConnected {
+			return exitAgentDown, "agent-unreachable"
+		}
+		return exitHealthy, "healthy"
+	case box.PhaseFailed:
+		return exitUnhealthy, "failed"
+	default:
+		return exitNotRunning, "not-running"
+	}
+}
+
+// statusJSON is one workspace's health in --json output (status, wait).
+type statusJSON struct {
+	Name      string          `json:"name"`
+	Health    string          `json:"health"`
+	Workspace json.RawMessage `json:"workspace,omitempty"`
+}
+
+func newStatusCmd() *cobra.Command {
+	var quiet bool
+	c := &cobra.Command{
+		Use:               "status <name|id>",
+		Short:             "Show a workspace's health (exit 0 healthy, 2 not running, 3 agent unreachable, 4 failed)",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeWorkspaceArg,
+		RunE: func(_ *cobra.Command, args []string) error {
+			client, closer, err := dial()
+			if err != nil {
+				return err
+			}
+			defer closer()
+			w, err := client.GetWorkspace(context.Background(), &hopboxv1.GetWorkspaceRequest{NameOrId: args[0]})
+			if err != nil {
+				return err
+			}
+			code, summary := workspaceHealth(w)
+			switch {
+			case quiet:
+			case jsonOut:
+				b, err := workspaceJSON(w)
+				if err != nil {
+					return err
+				}
+				if err := printJSON(statusJSON{Name: w.Name, Health: summary, Workspace: b}); err != nil {
+					return err
+				}
+			default:
+				fmt.Printf("%s: %s (phase=%s agent=%v)\n", w.Name, summary, w.Phase, w.AgentConnected)
+				for _, e := range w.Endpoints {
+					fmt.Printf("  %s  %s\n", e.Name, e.Url)
+				}
+				if msg := strings.TrimSpace(w.Message); msg != "" {
+					fmt.Printf("  message: %s\n", msg)
+				}
+			}
+			if code != exitHealthy {
+				os.Exit(code)
+			}
+			return nil
+		},
+	}
+	c.Flags().BoolVarP(&quiet, "quiet", "q", false, "print nothing; report health through the exit code only")
+	return c
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+
+	hopboxv1 "github.com/hopboxdev/hopbox/gen/hopbox/v1"
+)
+
+func TestWorkspaceHealth(t *testing.T) {
+	for _, tc := range []struct {
+		phase string
+		agent bool
+		want  int
+	}{
+		{"Running", true, exitHealthy},
+		{"Running", false, exitAgentDown},
+		{"Provisioning", false, exitNotRunning},
+		{"Suspended", false, exitNotRunning},
+		{"Failed", false, exitUnhealthy},
+	} {
+		got, _ := workspaceHealth(&hopboxv1.Workspace{Phase: tc.phase, AgentConnected: tc.agent})
+		if got != tc.want {
+			t.Errorf("%s agent=%v: exit %d, want %d", tc.phase, tc.agent, got, tc.want)
+		}
+	}
+}
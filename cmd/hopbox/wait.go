@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	hopboxv1 "github.com/hopboxdev/hopbox/gen/hopbox/v1"
+)
+
+// getWorkspaceFunc fetches one workspace by name or id (client.GetWorkspace,
+// or a fake in tests).
+type getWorkspaceFunc func(ctx context.Context, nameOrID string) (*hopboxv1.Workspace, error)
+
+// waitHealthy polls until every named workspace is healthy (running with its
+// agent connected), one of them has failed, or ctx ends. It returns each
+// workspace's last status exit code (see workspaceHealth) and summary. Failed
+// is final, so it ends the wait at once rather than at the deadline. A
+// workspace that does not exist is an error; an unreachable server is retried,
+// since hopboxd may be restarting, and if it stays unreachable the result is
+// exitError, as from `status`.
+func waitHealthy(ctx context.Context, get getWorkspaceFunc, names []string, interval time.Duration) (map[string]int, map[string]string, error) {
+	results, summaries := map[string]int{}, map[string]string{}
+	for _, n := range names {
+		results[n], summaries[n] = exitNotRunning, "unknown"
+	}
+	for {
+		pending, failed := 0, false
+		for _, n := range names {
+			if results[n] == exitHealthy {
+				continue
+			}
+			w, err := get(ctx, n)
+			switch {
+			case err == nil:
+				results[n], summaries[n] = workspaceHealth(w)
+			case status.Code(err) == codes.Unavailable:
+				results[n], summaries[n] = exitError, "server-unreachable"
+			case ctx.Err() != nil: // deadline hit mid-poll; keep the last result
+			default:
+				return nil, nil, err
+			}
+			if results[n] != exitHealthy {
+				pending++
+			}
+			failed = failed || results[n] == exitUnhealthy
+		}
+		if pending == 0 || failed {
+			return results, summaries, nil
+		}
+		select {
+		case <-ctx.Done():
+			return results, summaries, nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+func newWaitCmd() *cobra.Command {
+	var timeout, interval time.Duration
+	c := &cobra.Command{
+		Use:               "wait <name|id>...",
+		Short:             "Block until workspaces are healthy (exit like `status` on failure or timeout)",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeWorkspaceArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			client, closer, err := dial()
+			if err != nil {
+				return err
+			}
+			defer closer()
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			get := func(ctx context.Context, n string) (*hopboxv1.Workspace, error) {
+				return client.GetWorkspace(ctx, &hopboxv1.GetWorkspaceRequest{NameOrId: n})
+			}
+			start := time.Now()
+			results, summaries, err := waitHealthy(ctx, get, args, interval)
+			if err != nil {
+				return err
+			}
+			// Exit with the worst status code, so `wait` and `status` agree.
+			worst := exitHealthy
+			out := make([]statusJSON, 0, len(args))
+			for _, n := range args {
+				if jsonOut {
+					out = append(out, statusJSON{Name: n, Health: summaries[n]})
+				} else {
+					fmt.Printf("%s: %s\n", n, summaries[n])
+				}
+				worst = max(worst, results[n])
+			}
+			if jsonOut {
+				if err := printJSON(out); err != nil {
+					return err
+				}
+			}
+			if worst == exitUnhealthy {
+				fmt.Fprintln(os.Stderr, "a workspace failed; see: hopbox status <name>")
+				os.Exit(worst)
+			}
+			if worst != exitHealthy {
+				fmt.Fprintf(os.Stderr, "timed out after %s\n", time.Since(start).Round(time.Second))
+				os.Exit(worst)
+			}
+			return nil
+		},
+	}
+	c.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "give up after this long")
+	c.Flags().DurationVar(&interval, "interval", 2*time.Second, "poll interval")
+	return c
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	hopboxv1 "github.com/hopboxdev/hopbox/gen/hopbox/v1"
+)
+
+// A workspace that becomes healthy ends the wait; one that never does is
+// reported with its last status code once the deadline passes.
+func TestWaitHealthy(t *testing.T) {
+	polls := 0
+	get := func(_ context.Context, n string) (*hopboxv1.Workspace, error) {
+		polls++
+		switch {
+		case n == "gone":
+			return nil, status.Error(codes.NotFound, "workspace \"gone\" not found")
+		case n == "web" && polls > 2:
+			return &hopboxv1.Workspace{Name: n, Phase: "Running", AgentConnected: true}, nil
+		case n == "db":
+			return &hopboxv1.Workspace{Name: n, Phase: "Running"}, nil
+		case n == "broken":
+			return &hopboxv1.Workspace{Name: n, Phase: "Failed"}, nil
+		case n == "far":
+			return nil, status.Error(codes.Unavailable, "connection refused")
+		}
+		return &hopboxv1.Workspace{Name: n, Phase: "Provisioning"}, nil
+	}
+
+	results, _, err := waitHealthy(context.Background(), get, []string{"web"}, time.Millisecond)
+	if err != nil || results["web"] != exitHealthy {
+		t.Fatalf("web: %v %v", results, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	results, summaries, err := waitHealthy(ctx, get, []string{"db"}, time.Millisecond)
+	if err != nil || results["db"] != exitAgentDown || summaries["db"] != "agent-unreachable" {
+		t.Fatalf("db: %v %v %v", results, summaries, err)
+	}
+
+	if _, _, err := waitHealthy(context.Background(), get, []string{"gone"}, time.Millisecond); status.Code(err) != codes.NotFound {
+		t.Fatalf("gone: want NotFound, got %v", err)
+	}
+
+	// Failed is final: the wait ends on the first poll, long before the deadline.
+	ctx, cancel = context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	start := time.Now()
+	results, summaries, err = waitHealthy(ctx, get, []string{"broken", "db"}, 10*time.Second)
+	if err != nil || results["broken"] != exitUnhealthy || summaries["broken"] != "failed" || time.Since(start) > 5*time.Second {
+		t.Fatalf("broken: %v %v %v after %s", results, summaries, err, time.Since(start))
+	}
+
+	// A server that stays unreachable exits like `status` does when it cannot dial.
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	results, summaries, err = waitHealthy(ctx, get, []string{"far"}, time.Millisecond)
+	if err != nil || results["far"] != exitError || summaries["far"] != "server-unreachable" {
+		t.Fatalf("far: %v %v %v", results, summaries, err)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Default paths of the self-signed API certificate (--api-tls-self-signed),
+// next to the other auto-created state like ./hopbox-ssh-ca.
+const (
+	defaultAPICert = "./hopbox-api-tls.crt"
+	defaultAPIKey  = "./hopbox-api-tls.key"
+)
+
+// loadOrCreateAPICert makes sure a certificate and key exist at certPath and
+// keyPath, generating a self-signed pair valid for hosts on first run. The pair
+// is kept, so clients can pin the certificate once with `hopbox --tls-ca`; to
+// change the names, delete both files and restart. Reports whether it created
+// them.
+func loadOrCreateAPICert(certPath, keyPath string, hosts []string) (bool, error) {
+	_, certErr := os.Stat(certPath)
+	_, keyErr := os.Stat(keyPath)
+	switch {
+	case certErr == nil && keyErr == nil:
+		return false, nil
+	case !errors.Is(certErr, os.ErrNotExist) && certErr != nil:
+		return false, certErr
+	case !errors.Is(keyErr, os.ErrNotExist) && keyErr != nil:
+		return false, keyErr
+	case certErr == nil || keyErr == nil:
+		return false, fmt.Errorf("only one of %s and %s exists: remove it to regenerate the pair", certPath, keyPath)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return false, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return false, err
+	}
+	tmpl := x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "hopboxd"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true, // its own CA, so `--tls-ca <cert>` verifies it
+	}
+	seen := map[string]bool{}
+	for _, h := range hosts {
+		if seen[h] {
+			continue
+		}
+		seen[h] = true
+		if ip := net.ParseIP(h); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else if h != "" {
+			tmpl.DNSNames = append(tmpl.DNSNames, h)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+	if err != nil {
+		return false, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range []string{certPath, keyPath} {
+		if dir := filepath.Dir(p); dir != "" {
+			_ = os.MkdirAll(dir, 0o700)
+		}
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// apiCertHosts are the names a self-signed API certificate is valid for: the
+// loopback names, this machine's hostname, the --api-addr host if it names one,
+// and any extra --api-tls-hosts.
+func apiCertHosts(apiAddr string, extra []string) []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if h, err := os.Hostname(); err == nil && h != "" {
+		hosts = append(hosts, h)
+	}
+	if h, _, err := net.SplitHostPort(apiAddr); err == nil && h != "" {
+		if ip := net.ParseIP(h); ip == nil || !ip.IsUnspecified() {
+			hosts = append(hosts, h)
+		}
+	}
+	return append(hosts, extra...)
+}
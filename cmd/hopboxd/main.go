@@ -12,9 +12,14 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"golang.org/x/crypto/ssh"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
 	hopboxv1 "github.com/hopboxdev/hopbox/gen/hopbox/v1"
 	"github.com/hopboxdev/hopbox/internal/account"
@@ -29,6 +34,7 @@ import (
 	"github.com/hopboxdev/hopbox/internal/plugin"
 	"github.com/hopboxdev/hopbox/internal/sshca"
 	"github.com/hopboxdev/hopbox/internal/sshfront"
+	"github.com/hopboxdev/hopbox/internal/statuspage"
 	"github.com/hopboxdev/hopbox/providers/identity/oidc"
 	"github.com/hopboxdev/hopbox/providers/identity/static"
 	"github.com/hopboxdev/hopbox/providers/ingress/subdomain"
@@ -42,6 +48,20 @@ func portOf(addr, def string) string {
 	return def
 }
 
+// isLoopback reports whether a listen address only accepts local connections.
+// An empty host (":7702") listens on every interface, so it is not loopback.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil || host == "" {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 // splitComma parses a comma-separated flag value, trimming blanks.
 func splitComma(s string) []string {
 	var out []string
@@ -83,22 +103,24 @@ func loadAuthorizedKeys(file string) string {
 	return ""
 }
 
-// loadUsers parses a token->principal file (lines `<token> <principal>`, '#'
-// comments) into the static identity provider's key map. Empty path => open
-// single-user mode (no entries).
-func loadUsers(file, tenant string) map[string]ports.Principal {
+// loadUsers parses a token->principal file (lines `<token> <principal>
+// [expires=<when>]`, '#' comments) into the static identity provider's key map
+// and the expiry times of the tokens that have one. <when> is RFC 3339 or a
+// date (midnight UTC). A line with a bad expiry is skipped, so a typo never
+// yields a token that lives forever; other trailing fields are ignored. Empty
+// path => open single-user mode (no entries).
+func loadUsers(file, tenant string) (map[string]ports.Principal, map[string]time.Time, error) {
 	if file == "" {
-		return nil
+		return nil, nil, nil
 	}
 	f, err := os.Open(file)
 	if err != nil {
-		log.Printf("hopboxd: users %s: %v (auth disabled)", file, err)
-		return nil
+		return nil, nil, err
 	}
 	defer f.Close()
-	users := map[string]ports.Principal{}
+	users, expires := map[string]ports.Principal{}, map[string]time.Time{}
 	sc := bufio.NewScanner(f)
-	for sc.Scan() {
+	for n := 1; sc.Scan(); n++ {
 		line := strings.TrimSpace(sc.Text())
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
@@ -107,9 +129,58 @@ func loadUsers(file, tenant string) map[string]ports.Principal {
 		if len(parts) < 2 {
 			continue
 		}
+		bad := false
+		for _, p := range parts[2:] {
+			v, ok := strings.CutPrefix(p, "expires=")
+			if !ok {
+				continue
+			}
+			exp, err := parseExpiry(v)
+			if err != nil {
+				log.Printf("hopboxd: users %s:%d: expires=%q: want an RFC 3339 time or YYYY-MM-DD (line skipped)", file, n, v)
+				bad = true
+				break
+			}
+			expires[parts[0]] = exp
+		}
+		if bad {
+			continue
+		}
 		users[parts[0]] = ports.Principal{ID: parts[1], TenantID: tenant, Roles: []string{"owner"}}
 	}
-	return users
+	return users, expires, sc.Err()
+}
+
+// reloadUsersOnHUP re-reads the users file on SIGHUP and swaps it into p, so
+// adding, expiring or revoking a token needs no restart (and no dropped shells).
+// A file that cannot be read keeps the current tokens.
+func reloadUsersOnHUP(ctx context.Context, p *static.Provider, file, tenant string) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			users, expires, err := loadUsers(file, tenant)
+			if err != nil {
+				log.Printf("hopboxd: reload users %s: %v (keeping the current tokens)", file, err)
+				continue
+			}
+			p.Replace(users, expires)
+			log.Printf("hopboxd: reloaded users: %d principals from %s", len(users), file)
+		}
+	}
+}
+
+// parseExpiry reads a users-file expiry: an RFC 3339 time, or a date meaning
+// midnight UTC at its start.
+func parseExpiry(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, v)
 }
 
 func run(cfg config.Config) error {
@@ -237,7 +308,8 @@ func run(cfg config.Config) error {
 		GuestBin: cfg.GuestBin, // side-load box-guest into docker boxes; microVM bakes it in
 		Hooks:    hooks,
 	})
-	go rec.Run(ctx)
+	recDone := make(chan struct{})
+	go func() { rec.Run(ctx); close(recDone) }()
 
 	// reconcile wake-up bus: in-proc by default (a direct call to rec.Trigger);
 	// NATS fans wake-ups across nodes. Either way the reconciler's interval sweep
@@ -358,12 +430,79 @@ func run(cfg config.Config) error {
 		})
 		log.Printf("hopboxd: OIDC auth on (issuer %s)", cfg.OIDCIssuer)
 	default:
-		if users := loadUsers(cfg.UsersFile, cfg.Tenant); len(users) > 0 {
-			idp = static.New(users)
-			log.Printf("hopboxd: multi-user auth on (%d principals from %s)", len(users), cfg.UsersFile)
+		users, expires, err := loadUsers(cfg.UsersFile, cfg.Tenant)
+		switch {
+		case err != nil:
+			log.Printf("hopboxd: users %s: %v (auth disabled)", cfg.UsersFile, err)
+		case len(users) > 0:
+			sp := static.New(users).WithExpiry(expires)
+			idp = sp
+			go reloadUsersOnHUP(ctx, sp, cfg.UsersFile, cfg.Tenant)
+			log.Printf("hopboxd: multi-user auth on (%d principals from %s; SIGHUP reloads it)", len(users), cfg.UsersFile)
 		}
 	}
 	var opts []grpc.ServerOption
+	if cfg.APITLSSelfSign {
+		if cfg.APITLSCert == "" && cfg.APITLSKey == "" {
+			cfg.APITLSCert, cfg.APITLSKey = defaultAPICert, defaultAPIKey
+		}
+		created, err := loadOrCreateAPICert(cfg.APITLSCert, cfg.APITLSKey, apiCertHosts(cfg.APIAddr, splitComma(cfg.APITLSHosts)))
+		if err != nil {
+			return fmt.Errorf("api tls: self-signed certificate: %w", err)
+		}
+		if created {
+			log.Printf("hopboxd: generated self-signed API certificate %s; clients pin it with: hopbox --tls-ca %s (copy the file to them)", cfg.APITLSCert, cfg.APITLSCert)
+		}
+	}
+	switch {
+	case (cfg.APITLSCert == "") != (cfg.APITLSKey == ""):
+		return fmt.Errorf("--api-tls-cert and --api-tls-key must be set together")
+	case cfg.APITLSCert != "":
+		creds, err := credentials.NewServerTLSFromFile(cfg.APITLSCert, cfg.APITLSKey)
+		if err != nil {
+			return fmt.Errorf("api tls: %w", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+		log.Printf("hopboxd: API TLS on (cert %s)", cfg.APITLSCert)
+	case idp != nil && !isLoopback(cfg.APIAddr):
+		log.Printf("hopboxd: warning: API on %s without TLS; api tokens cross the network in clear text (set --api-tls-cert/--api-tls-key)", cfg.APIAddr)
+	}
+	// Read-only web status page (opt-in): a glance at every workspace from a
+	// browser or phone. It lists every owner's workspaces, so it is always
+	// token-gated, even on localhost (other local users could read it). The token
+	// travels in a header, so it shares the API's certificate when there is one.
+	if cfg.StatusAddr != "" {
+		token, tokenFrom := cfg.StatusToken, "--status-token"
+		if token == "" {
+			if token, err = statuspage.LoadOrCreateToken(cfg.StatusTokenFile); err != nil {
+				return fmt.Errorf("status token: %w", err)
+			}
+			tokenFrom = cfg.StatusTokenFile
+		}
+		stLn, err := net.Listen("tcp", cfg.StatusAddr)
+		if err != nil {
+			return fmt.Errorf("status listen %s: %w", cfg.StatusAddr, err)
+		}
+		stSrv := &http.Server{Handler: statuspage.New(st.ListAll, token).Handler()}
+		go func() { <-ctx.Done(); _ = stSrv.Close() }()
+		switch {
+		case cfg.APITLSCert != "":
+			log.Printf("hopboxd: status page TLS on (cert %s)", cfg.APITLSCert)
+		case !isLoopback(cfg.StatusAddr):
+			log.Printf("hopboxd: warning: status page on %s without TLS; its token crosses the network in clear text (set --api-tls-cert/--api-tls-key, or --api-tls-self-signed)", cfg.StatusAddr)
+		}
+		go func() {
+			log.Printf("hopboxd: status page on %s (token from %s)", cfg.StatusAddr, tokenFrom)
+			serve := func() error { return stSrv.Serve(stLn) }
+			if cfg.APITLSCert != "" {
+				serve = func() error { return stSrv.ServeTLS(stLn, cfg.APITLSCert, cfg.APITLSKey) }
+			}
+			if err := serve(); err != nil && err != http.ErrServerClosed {
+				log.Printf("hopboxd: status page stopped: %v", err)
+			}
+		}()
+	}
+
 	if idp != nil {
 		opts = append(opts,
 			grpc.UnaryInterceptor(api.AuthUnaryInterceptor(idp)),
@@ -372,8 +511,41 @@ func run(cfg config.Config) error {
 	}
 	gs := grpc.NewServer(opts...)
 	hopboxv1.RegisterWorkspaceServiceServer(gs, api.NewServer(st, hub, cfg.Tenant, cfg.Owner, caSigner))
-	go func() { <-ctx.Done(); gs.GracefulStop() }()
+	hs := health.NewServer() // grpc.health.v1, unauthenticated for load balancers
+	healthpb.RegisterHealthServer(gs, hs)
+	if cfg.APIReflection {
+		reflection.Register(gs)
+	}
+
+	// Drain on shutdown: report NOT_SERVING and keep serving for --drain-delay,
+	// so probes see it and load balancers stop routing here; then refuse new
+	// calls and let in-flight ones finish — but cut off long-lived shells/execs
+	// after --drain-timeout so shutdown is bounded.
+	drained := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		hs.Shutdown()
+		if cfg.DrainDelay > 0 {
+			log.Printf("hopboxd: NOT_SERVING; closing the API in %s", cfg.DrainDelay)
+			time.Sleep(cfg.DrainDelay)
+		}
+		stop := time.AfterFunc(cfg.DrainTimeout, gs.Stop)
+		gs.GracefulStop()
+		stop.Stop()
+		close(drained)
+	}()
 
 	log.Printf("hopboxd: API on %s", cfg.APIAddr)
-	return gs.Serve(apiLn)
+	if err := gs.Serve(apiLn); err != nil {
+		return err
+	}
+	<-drained
+	// Let the reconciler finish the step it is on (a provision, say) instead of
+	// abandoning a half-made workspace; it takes no new work once ctx is done.
+	select {
+	case <-recDone:
+	case <-time.After(cfg.DrainTimeout):
+		log.Printf("hopboxd: reconciler still busy after %s; exiting anyway", cfg.DrainTimeout)
+	}
+	return nil
 }
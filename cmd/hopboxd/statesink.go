@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"strings"
 
 	"github.com/hopboxdev/hopbox/internal/core/store"
 )
@@ -24,6 +25,9 @@ func (s storeSink) SetAgentConnected(ctx context.Context, workspaceID string, co
 		return
 	}
 	w.AgentConnected = connected
+	if connected && strings.HasPrefix(w.Message, agentRejected) {
+		w.Message = "agent connected" // a current agent replaced the refused one
+	}
 	if err := s.store.UpdateWorkspace(ctx, w); err != nil {
 		log.Printf("statesink: update %s: %v", workspaceID, err)
 		return
@@ -32,3 +36,23 @@ func (s storeSink) SetAgentConnected(ctx context.Context, workspaceID string, co
 		s.trigger(workspaceID, s.tenant)
 	}
 }
+
+// agentRejected prefixes the agenthub's rejection reasons (agenthub.RejectSink).
+const agentRejected = "agent rejected: "
+
+// AgentRejected records why the hub refused a workspace's agent on its
+// Message, where `hopbox status` and the CLI hints show it.
+func (s storeSink) AgentRejected(ctx context.Context, workspaceID, reason string) {
+	w, err := s.store.GetWorkspace(ctx, s.tenant, workspaceID)
+	if err != nil {
+		log.Printf("statesink: get %s: %v", workspaceID, err)
+		return
+	}
+	if w.Message == reason {
+		return
+	}
+	w.Message = reason
+	if err := s.store.UpdateWorkspace(ctx, w); err != nil {
+		log.Printf("statesink: update %s: %v", workspaceID, err)
+	}
+}
@@ -25,16 +25,63 @@ log() { printf '\033[1;35m==>\033[0m %s\n' "$*"; }
 die() { printf '\033[1;31merror:\033[0m %s\n' "$*" >&2; exit 1; }
 
 [ "$(id -u)" = 0 ] || die "run as root (e.g. via sudo)"
-command -v docker >/dev/null 2>&1 || die "Docker is required but not installed"
-command -v systemctl >/dev/null 2>&1 || die "systemd is required"
-
 case "$(uname -s)" in Linux) ;; *) die "Linux only" ;; esac
+
+# --- distro family: only used to name the packages to install when missing ---
+FAMILY=unknown
+DISTRO=unknown
+if [ -r /etc/os-release ]; then
+  DISTRO="$(. /etc/os-release; echo "${ID:-unknown}")"
+  # Subshell: os-release sets VERSION, which would clobber ours.
+  for id in $(. /etc/os-release; echo "${ID:-} ${ID_LIKE:-}"); do
+    case "$id" in
+      debian|ubuntu) FAMILY=debian ;;
+      fedora|rhel|centos|rocky|almalinux|amzn) FAMILY=rhel ;;
+      arch|manjaro) FAMILY=arch ;;
+      alpine) FAMILY=alpine ;;
+      suse|opensuse*|sles) FAMILY=suse ;;
+      *) continue ;;
+    esac
+    break
+  done
+fi
+# need <command> <debian-pkg> <rhel-pkg> <arch-pkg> <alpine-pkg> <suse-pkg>
+need() {
+  command -v "$1" >/dev/null 2>&1 && return 0
+  case "$FAMILY" in
+    debian) hint="apt-get install -y $2" ;;
+    rhel)   hint="dnf install -y $3" ;;
+    arch)   hint="pacman -S --needed $4" ;;
+    alpine) hint="apk add $5" ;;
+    suse)   hint="zypper install -y $6" ;;
+    *)      hint="install $1 with your package manager" ;;
+  esac
+  die "$1 is required but not installed (try: $hint)"
+}
+need curl curl curl curl curl curl
+need tar tar tar tar tar tar
+# moby-engine is Fedora's own package; Amazon Linux ships docker, and RHEL and
+# its rebuilds get docker-ce from Docker's repo (hopboxd needs the docker
+# daemon itself, so podman-docker will not do).
+case "$DISTRO" in
+  fedora) rhel_docker="moby-engine" ;;
+  amzn)   rhel_docker="docker" ;;
+  rhel)   rhel_docker="dnf-plugins-core && dnf config-manager --add-repo https://download.docker.com/linux/rhel/docker-ce.repo && dnf install -y docker-ce" ;;
+  *)      rhel_docker="dnf-plugins-core && dnf config-manager --add-repo https://download.docker.com/linux/centos/docker-ce.repo && dnf install -y docker-ce" ;;
+esac
+need docker docker.io "$rhel_docker" docker docker docker
+# systemctl alone is not enough: it is present in many containers and on
+# OpenRC hosts where systemd is not the running init.
+if ! command -v systemctl >/dev/null 2>&1 || [ ! -d /run/systemd/system ]; then
+  die "systemd is required (non-systemd hosts such as Alpine/OpenRC: run hopboxd and hopbox-gw under your init system; see https://github.com/$REPO/blob/main/deploy/README.md#hosts-without-systemd-openrc)"
+fi
+
 case "$(uname -m)" in
   x86_64|amd64) ARCH=amd64 ;;
   aarch64|arm64) ARCH=arm64 ;;
   *) die "unsupported arch $(uname -m)" ;;
 esac
-log "platform: linux/$ARCH"
+log "platform: linux/$ARCH ($FAMILY)"
 
 # --- docker bridge gateway: the address workspace containers reverse-dial ---
 BRIDGE="$(ip -4 addr show docker0 2>/dev/null | grep -oE 'inet [0-9.]+' | awk '{print $2}' | head -1)"
@@ -63,6 +110,22 @@ install -m755 "$tmp/hopboxd" "$tmp/hopbox" "$tmp/hopbox-gw" "$PREFIX/"
 mkdir -p "$LIBDIR"
 install -m755 "$tmp/hopbox-agent" "$LIBDIR/hopbox-agent-linux-$ARCH"
 log "installed binaries to $PREFIX and the agent to $LIBDIR"
+# SELinux (Fedora/RHEL): containers may only execute container-labelled files,
+# and the agent is bind-mounted into every workspace. The label goes into the
+# policy (semanage) so a relabel keeps it; chcon alone is lost on the next one.
+if command -v getenforce >/dev/null 2>&1 && [ "$(getenforce)" = Enforcing ]; then
+  agent="$LIBDIR/hopbox-agent-linux-$ARCH"
+  if command -v semanage >/dev/null 2>&1 &&
+    { semanage fcontext -a -t container_file_t "$agent" 2>/dev/null ||
+      semanage fcontext -m -t container_file_t "$agent" 2>/dev/null; } &&
+    restorecon "$agent" 2>/dev/null; then
+    log "selinux: labelled the agent container_file_t"
+  elif chcon -t container_file_t "$agent" 2>/dev/null; then
+    log "warning: semanage not found; labelled the agent with chcon, which a relabel undoes (install policycoreutils-python-utils and re-run)"
+  else
+    log "warning: could not label the agent container_file_t; workspaces may fail to start it"
+  fi
+fi
 
 # --- config (created once; edit then re-run to apply) ---
 mkdir -p "$ETCDIR"
@@ -130,12 +193,21 @@ EOF
 log "installed systemd units"
 
 # --- firewall: let workspace containers reverse-dial the agent port ---
+agentport="$(printf '%s' "$HOPBOX_AGENT_LISTEN" | sed 's/^.*://')"
 if command -v ufw >/dev/null 2>&1 && ufw status 2>/dev/null | grep -q "Status: active"; then
-  agentport="$(printf '%s' "$HOPBOX_AGENT_LISTEN" | sed 's/^.*://')"
   if ! ufw status | grep -q "${agentport}.*ALLOW.*docker0"; then
     ufw allow in on docker0 to any port "$agentport" proto tcp >/dev/null 2>&1 || true
     log "ufw: allowed docker0 -> :$agentport (agent reverse-dial)"
   fi
+elif command -v firewall-cmd >/dev/null 2>&1 && firewall-cmd --state >/dev/null 2>&1; then
+  # Open the port only in docker0's own zone, never the public default zone.
+  zone="$(firewall-cmd --get-zone-of-interface=docker0 2>/dev/null || true)"
+  if [ -z "$zone" ]; then
+    log "warning: firewalld has docker0 in no zone; if workspaces cannot reach hopboxd, allow docker0 -> :$agentport"
+  elif ! firewall-cmd --permanent --zone="$zone" --query-port="$agentport/tcp" >/dev/null 2>&1; then
+    firewall-cmd --permanent --zone="$zone" --add-port="$agentport/tcp" >/dev/null && firewall-cmd --reload >/dev/null
+    log "firewalld: allowed :$agentport in zone $zone (docker0, agent reverse-dial)"
+  fi
 fi
 
 # --- optional Caddy gateway block (wildcard HTTPS via on-demand certs) ---
@@ -33,7 +33,8 @@ type Workspace struct {
 	Phase          string                 `protobuf:"bytes,7,opt,name=phase,proto3" json:"phase,omitempty"`
 	AgentConnected bool                   `protobuf:"varint,8,opt,name=agent_connected,json=agentConnected,proto3" json:"agent_connected,omitempty"`
 	Message        string                 `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"`
-	Endpoints      []*Endpoint            `protobuf:"bytes,10,rep,name=endpoints,proto3" json:"endpoints,omitempty"` // resolved ingress endpoints (status)
+	Endpoints      []*Endpoint            `protobuf:"bytes,10,rep,name=endpoints,proto3" json:"endpoints,omitempty"`                   // resolved ingress endpoints (status)
+	CpuMillis      int64                  `protobuf:"varint,11,opt,name=cpu_millis,json=cpuMillis,proto3" json:"cpu_millis,omitempty"` // CPU limit in milli-cores (1000 = 1 CPU); 0 = unlimited
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -138,6 +139,13 @@ func (x *Workspace) GetEndpoints() []*Endpoint {
 	return nil
 }
 
+func (x *Workspace) GetCpuMillis() int64 {
+	if x != nil {
+		return x.CpuMillis
+	}
+	return 0
+}
+
 // IngressPort is a desired exposed port (spec); Endpoint is its resolved address (status).
 type IngressPort struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -256,7 +264,8 @@ type CreateWorkspaceRequest struct {
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	ImageRef      string                 `protobuf:"bytes,2,opt,name=image_ref,json=imageRef,proto3" json:"image_ref,omitempty"`
 	MemMb         int64                  `protobuf:"varint,3,opt,name=mem_mb,json=memMb,proto3" json:"mem_mb,omitempty"`
-	Ingress       []*IngressPort         `protobuf:"bytes,4,rep,name=ingress,proto3" json:"ingress,omitempty"` // ports to expose at the gateway
+	Ingress       []*IngressPort         `protobuf:"bytes,4,rep,name=ingress,proto3" json:"ingress,omitempty"`                       // ports to expose at the gateway
+	CpuMillis     int64                  `protobuf:"varint,5,opt,name=cpu_millis,json=cpuMillis,proto3" json:"cpu_millis,omitempty"` // CPU limit in milli-cores; 0 = unlimited
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -319,6 +328,13 @@ func (x *CreateWorkspaceRequest) GetIngress() []*IngressPort {
 	return nil
 }
 
+func (x *CreateWorkspaceRequest) GetCpuMillis() int64 {
+	if x != nil {
+		return x.CpuMillis
+	}
+	return 0
+}
+
 type GetWorkspaceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NameOrId      string                 `protobuf:"bytes,1,opt,name=name_or_id,json=nameOrId,proto3" json:"name_or_id,omitempty"`
@@ -1197,6 +1213,188 @@ func (x *SSHServerMsg) GetData() []byte {
 	return nil
 }
 
+// Forward tunnels one TCP connection to a port inside the workspace (`hopbox
+// forward`): first client msg MUST be `open` (workspace + in-box port); every
+// later msg in both directions carries opaque TCP bytes. One stream per
+// connection — the CLI opens a new one for each accepted local connection.
+type ForwardClientMsg struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Msg:
+	//
+	//	*ForwardClientMsg_Open
+	//	*ForwardClientMsg_Data
+	Msg           isForwardClientMsg_Msg `protobuf_oneof:"msg"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForwardClientMsg) Reset() {
+	*x = ForwardClientMsg{}
+	mi := &file_hopbox_v1_hopbox_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForwardClientMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardClientMsg) ProtoMessage() {}
+
+func (x *ForwardClientMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_hopbox_v1_hopbox_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardClientMsg.ProtoReflect.Descriptor instead.
+func (*ForwardClientMsg) Descriptor() ([]byte, []int) {
+	return file_hopbox_v1_hopbox_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ForwardClientMsg) GetMsg() isForwardClientMsg_Msg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *ForwardClientMsg) GetOpen() *ForwardOpen {
+	if x != nil {
+		if x, ok := x.Msg.(*ForwardClientMsg_Open); ok {
+			return x.Open
+		}
+	}
+	return nil
+}
+
+func (x *ForwardClientMsg) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Msg.(*ForwardClientMsg_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+type isForwardClientMsg_Msg interface {
+	isForwardClientMsg_Msg()
+}
+
+type ForwardClientMsg_Open struct {
+	Open *ForwardOpen `protobuf:"bytes,1,opt,name=open,proto3,oneof"`
+}
+
+type ForwardClientMsg_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*ForwardClientMsg_Open) isForwardClientMsg_Msg() {}
+
+func (*ForwardClientMsg_Data) isForwardClientMsg_Msg() {}
+
+type ForwardOpen struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NameOrId      string                 `protobuf:"bytes,1,opt,name=name_or_id,json=nameOrId,proto3" json:"name_or_id,omitempty"`
+	Port          uint32                 `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForwardOpen) Reset() {
+	*x = ForwardOpen{}
+	mi := &file_hopbox_v1_hopbox_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForwardOpen) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardOpen) ProtoMessage() {}
+
+func (x *ForwardOpen) ProtoReflect() protoreflect.Message {
+	mi := &file_hopbox_v1_hopbox_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardOpen.ProtoReflect.Descriptor instead.
+func (*ForwardOpen) Descriptor() ([]byte, []int) {
+	return file_hopbox_v1_hopbox_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ForwardOpen) GetNameOrId() string {
+	if x != nil {
+		return x.NameOrId
+	}
+	return ""
+}
+
+func (x *ForwardOpen) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+type ForwardServerMsg struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForwardServerMsg) Reset() {
+	*x = ForwardServerMsg{}
+	mi := &file_hopbox_v1_hopbox_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForwardServerMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardServerMsg) ProtoMessage() {}
+
+func (x *ForwardServerMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_hopbox_v1_hopbox_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardServerMsg.ProtoReflect.Descriptor instead.
+func (*ForwardServerMsg) Descriptor() ([]byte, []int) {
+	return file_hopbox_v1_hopbox_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ForwardServerMsg) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
 // IssueSSHCert signs the caller's SSH public key into a short-lived user
 // certificate (`hopbox login`). The cert names the caller's principal; boxes
 // trust the CA and admit only certs for their owner's principal.
@@ -1209,7 +1407,7 @@ type IssueSSHCertRequest struct {
 
 func (x *IssueSSHCertRequest) Reset() {
 	*x = IssueSSHCertRequest{}
-	mi := &file_hopbox_v1_hopbox_proto_msgTypes[18]
+	mi := &file_hopbox_v1_hopbox_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1221,7 +1419,7 @@ func (x *IssueSSHCertRequest) String() string {
 func (*IssueSSHCertRequest) ProtoMessage() {}
 
 func (x *IssueSSHCertRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hopbox_v1_hopbox_proto_msgTypes[18]
+	mi := &file_hopbox_v1_hopbox_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1234,7 +1432,7 @@ func (x *IssueSSHCertRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IssueSSHCertRequest.ProtoReflect.Descriptor instead.
 func (*IssueSSHCertRequest) Descriptor() ([]byte, []int) {
-	return file_hopbox_v1_hopbox_proto_rawDescGZIP(), []int{18}
+	return file_hopbox_v1_hopbox_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *IssueSSHCertRequest) GetPublicKey() string {
@@ -1255,7 +1453,7 @@ type IssueSSHCertResponse struct {
 
 func (x *IssueSSHCertResponse) Reset() {
 	*x = IssueSSHCertResponse{}
-	mi := &file_hopbox_v1_hopbox_proto_msgTypes[19]
+	mi := &file_hopbox_v1_hopbox_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1267,7 +1465,7 @@ func (x *IssueSSHCertResponse) String() string {
 func (*IssueSSHCertResponse) ProtoMessage() {}
 
 func (x *IssueSSHCertResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hopbox_v1_hopbox_proto_msgTypes[19]
+	mi := &file_hopbox_v1_hopbox_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1280,7 +1478,7 @@ func (x *IssueSSHCertResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IssueSSHCertResponse.ProtoReflect.Descriptor instead.
 func (*IssueSSHCertResponse) Descriptor() ([]byte, []int) {
-	return file_hopbox_v1_hopbox_proto_rawDescGZIP(), []int{19}
+	return file_hopbox_v1_hopbox_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *IssueSSHCertResponse) GetCertificate() string {
@@ -1308,7 +1506,7 @@ var File_hopbox_v1_hopbox_proto protoreflect.FileDescriptor
 
 const file_hopbox_v1_hopbox_proto_rawDesc = "" +
 	"\n" +
-	"\x16hopbox/v1/hopbox.proto\x12\thopbox.v1\x1a\x1bgoogle/protobuf/empty.proto\"\xa2\x02\n" +
+	"\x16hopbox/v1/hopbox.proto\x12\thopbox.v1\x1a\x1bgoogle/protobuf/empty.proto\"\xc1\x02\n" +
 	"\tWorkspace\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x14\n" +
@@ -1320,19 +1518,23 @@ const file_hopbox_v1_hopbox_proto_rawDesc = "" +
 	"\x0fagent_connected\x18\b \x01(\bR\x0eagentConnected\x12\x18\n" +
 	"\amessage\x18\t \x01(\tR\amessage\x121\n" +
 	"\tendpoints\x18\n" +
-	" \x03(\v2\x13.hopbox.v1.EndpointR\tendpoints\"5\n" +
+	" \x03(\v2\x13.hopbox.v1.EndpointR\tendpoints\x12\x1d\n" +
+	"\n" +
+	"cpu_millis\x18\v \x01(\x03R\tcpuMillis\"5\n" +
 	"\vIngressPort\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04port\x18\x02 \x01(\x05R\x04port\"D\n" +
 	"\bEndpoint\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
 	"\x03url\x18\x02 \x01(\tR\x03url\x12\x12\n" +
-	"\x04port\x18\x03 \x01(\x05R\x04port\"\x92\x01\n" +
+	"\x04port\x18\x03 \x01(\x05R\x04port\"\xb1\x01\n" +
 	"\x16CreateWorkspaceRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
 	"\timage_ref\x18\x02 \x01(\tR\bimageRef\x12\x15\n" +
 	"\x06mem_mb\x18\x03 \x01(\x03R\x05memMb\x120\n" +
-	"\aingress\x18\x04 \x03(\v2\x16.hopbox.v1.IngressPortR\aingress\"3\n" +
+	"\aingress\x18\x04 \x03(\v2\x16.hopbox.v1.IngressPortR\aingress\x12\x1d\n" +
+	"\n" +
+	"cpu_millis\x18\x05 \x01(\x03R\tcpuMillis\"3\n" +
 	"\x13GetWorkspaceRequest\x12\x1c\n" +
 	"\n" +
 	"name_or_id\x18\x01 \x01(\tR\bnameOrId\"\x17\n" +
@@ -1383,6 +1585,16 @@ const file_hopbox_v1_hopbox_proto_rawDesc = "" +
 	"\n" +
 	"name_or_id\x18\x01 \x01(\tR\bnameOrId\"\"\n" +
 	"\fSSHServerMsg\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"]\n" +
+	"\x10ForwardClientMsg\x12,\n" +
+	"\x04open\x18\x01 \x01(\v2\x16.hopbox.v1.ForwardOpenH\x00R\x04open\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04dataB\x05\n" +
+	"\x03msg\"?\n" +
+	"\vForwardOpen\x12\x1c\n" +
+	"\n" +
+	"name_or_id\x18\x01 \x01(\tR\bnameOrId\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\rR\x04port\"&\n" +
+	"\x10ForwardServerMsg\x12\x12\n" +
 	"\x04data\x18\x01 \x01(\fR\x04data\"4\n" +
 	"\x13IssueSSHCertRequest\x12\x1d\n" +
 	"\n" +
@@ -1390,7 +1602,7 @@ const file_hopbox_v1_hopbox_proto_rawDesc = "" +
 	"\x14IssueSSHCertResponse\x12 \n" +
 	"\vcertificate\x18\x01 \x01(\tR\vcertificate\x12\x1c\n" +
 	"\tprincipal\x18\x02 \x01(\tR\tprincipal\x12*\n" +
-	"\x11valid_before_unix\x18\x03 \x01(\x03R\x0fvalidBeforeUnix2\xda\x04\n" +
+	"\x11valid_before_unix\x18\x03 \x01(\x03R\x0fvalidBeforeUnix2\xa3\x05\n" +
 	"\x10WorkspaceService\x12J\n" +
 	"\x0fCreateWorkspace\x12!.hopbox.v1.CreateWorkspaceRequest\x1a\x14.hopbox.v1.Workspace\x12D\n" +
 	"\fGetWorkspace\x12\x1e.hopbox.v1.GetWorkspaceRequest\x1a\x14.hopbox.v1.Workspace\x12U\n" +
@@ -1398,7 +1610,8 @@ const file_hopbox_v1_hopbox_proto_rawDesc = "" +
 	"\x0fDeleteWorkspace\x12!.hopbox.v1.DeleteWorkspaceRequest\x1a\x16.google.protobuf.Empty\x12A\n" +
 	"\x05Shell\x12\x19.hopbox.v1.ShellClientMsg\x1a\x19.hopbox.v1.ShellServerMsg(\x010\x01\x12>\n" +
 	"\x04Exec\x12\x18.hopbox.v1.ExecClientMsg\x1a\x18.hopbox.v1.ExecServerMsg(\x010\x01\x12;\n" +
-	"\x03SSH\x12\x17.hopbox.v1.SSHClientMsg\x1a\x17.hopbox.v1.SSHServerMsg(\x010\x01\x12O\n" +
+	"\x03SSH\x12\x17.hopbox.v1.SSHClientMsg\x1a\x17.hopbox.v1.SSHServerMsg(\x010\x01\x12G\n" +
+	"\aForward\x12\x1b.hopbox.v1.ForwardClientMsg\x1a\x1b.hopbox.v1.ForwardServerMsg(\x010\x01\x12O\n" +
 	"\fIssueSSHCert\x12\x1e.hopbox.v1.IssueSSHCertRequest\x1a\x1f.hopbox.v1.IssueSSHCertResponseB\x95\x01\n" +
 	"\rcom.hopbox.v1B\vHopboxProtoP\x01Z2github.com/hopboxdev/hopbox/gen/hopbox/v1;hopboxv1\xa2\x02\x03HXX\xaa\x02\tHopbox.V1\xca\x02\tHopbox\\V1\xe2\x02\x15Hopbox\\V1\\GPBMetadata\xea\x02\n" +
 	"Hopbox::V1b\x06proto3"
@@ -1415,7 +1628,7 @@ func file_hopbox_v1_hopbox_proto_rawDescGZIP() []byte {
 	return file_hopbox_v1_hopbox_proto_rawDescData
 }
 
-var file_hopbox_v1_hopbox_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_hopbox_v1_hopbox_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_hopbox_v1_hopbox_proto_goTypes = []any{
 	(*Workspace)(nil),              // 0: hopbox.v1.Workspace
 	(*IngressPort)(nil),            // 1: hopbox.v1.IngressPort
@@ -1435,9 +1648,12 @@ var file_hopbox_v1_hopbox_proto_goTypes = []any{
 	(*SSHClientMsg)(nil),           // 15: hopbox.v1.SSHClientMsg
 	(*SSHOpen)(nil),                // 16: hopbox.v1.SSHOpen
 	(*SSHServerMsg)(nil),           // 17: hopbox.v1.SSHServerMsg
-	(*IssueSSHCertRequest)(nil),    // 18: hopbox.v1.IssueSSHCertRequest
-	(*IssueSSHCertResponse)(nil),   // 19: hopbox.v1.IssueSSHCertResponse
-	(*emptypb.Empty)(nil),          // 20: google.protobuf.Empty
+	(*ForwardClientMsg)(nil),       // 18: hopbox.v1.ForwardClientMsg
+	(*ForwardOpen)(nil),            // 19: hopbox.v1.ForwardOpen
+	(*ForwardServerMsg)(nil),       // 20: hopbox.v1.ForwardServerMsg
+	(*IssueSSHCertRequest)(nil),    // 21: hopbox.v1.IssueSSHCertRequest
+	(*IssueSSHCertResponse)(nil),   // 22: hopbox.v1.IssueSSHCertResponse
+	(*emptypb.Empty)(nil),          // 23: google.protobuf.Empty
 }
 var file_hopbox_v1_hopbox_proto_depIdxs = []int32{
 	2,  // 0: hopbox.v1.Workspace.endpoints:type_name -> hopbox.v1.Endpoint
@@ -1447,27 +1663,30 @@ var file_hopbox_v1_hopbox_proto_depIdxs = []int32{
 	10, // 4: hopbox.v1.ShellClientMsg.resize:type_name -> hopbox.v1.Resize
 	13, // 5: hopbox.v1.ExecClientMsg.open:type_name -> hopbox.v1.ExecOpen
 	16, // 6: hopbox.v1.SSHClientMsg.open:type_name -> hopbox.v1.SSHOpen
-	3,  // 7: hopbox.v1.WorkspaceService.CreateWorkspace:input_type -> hopbox.v1.CreateWorkspaceRequest
-	4,  // 8: hopbox.v1.WorkspaceService.GetWorkspace:input_type -> hopbox.v1.GetWorkspaceRequest
-	5,  // 9: hopbox.v1.WorkspaceService.ListWorkspaces:input_type -> hopbox.v1.ListWorkspacesRequest
-	7,  // 10: hopbox.v1.WorkspaceService.DeleteWorkspace:input_type -> hopbox.v1.DeleteWorkspaceRequest
-	8,  // 11: hopbox.v1.WorkspaceService.Shell:input_type -> hopbox.v1.ShellClientMsg
-	12, // 12: hopbox.v1.WorkspaceService.Exec:input_type -> hopbox.v1.ExecClientMsg
-	15, // 13: hopbox.v1.WorkspaceService.SSH:input_type -> hopbox.v1.SSHClientMsg
-	18, // 14: hopbox.v1.WorkspaceService.IssueSSHCert:input_type -> hopbox.v1.IssueSSHCertRequest
-	0,  // 15: hopbox.v1.WorkspaceService.CreateWorkspace:output_type -> hopbox.v1.Workspace
-	0,  // 16: hopbox.v1.WorkspaceService.GetWorkspace:output_type -> hopbox.v1.Workspace
-	6,  // 17: hopbox.v1.WorkspaceService.ListWorkspaces:output_type -> hopbox.v1.ListWorkspacesResponse
-	20, // 18: hopbox.v1.WorkspaceService.DeleteWorkspace:output_type -> google.protobuf.Empty
-	11, // 19: hopbox.v1.WorkspaceService.Shell:output_type -> hopbox.v1.ShellServerMsg
-	14, // 20: hopbox.v1.WorkspaceService.Exec:output_type -> hopbox.v1.ExecServerMsg
-	17, // 21: hopbox.v1.WorkspaceService.SSH:output_type -> hopbox.v1.SSHServerMsg
-	19, // 22: hopbox.v1.WorkspaceService.IssueSSHCert:output_type -> hopbox.v1.IssueSSHCertResponse
-	15, // [15:23] is the sub-list for method output_type
-	7,  // [7:15] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	19, // 7: hopbox.v1.ForwardClientMsg.open:type_name -> hopbox.v1.ForwardOpen
+	3,  // 8: hopbox.v1.WorkspaceService.CreateWorkspace:input_type -> hopbox.v1.CreateWorkspaceRequest
+	4,  // 9: hopbox.v1.WorkspaceService.GetWorkspace:input_type -> hopbox.v1.GetWorkspaceRequest
+	5,  // 10: hopbox.v1.WorkspaceService.ListWorkspaces:input_type -> hopbox.v1.ListWorkspacesRequest
+	7,  // 11: hopbox.v1.WorkspaceService.DeleteWorkspace:input_type -> hopbox.v1.DeleteWorkspaceRequest
+	8,  // 12: hopbox.v1.WorkspaceService.Shell:input_type -> hopbox.v1.ShellClientMsg
+	12, // 13: hopbox.v1.WorkspaceService.Exec:input_type -> hopbox.v1.ExecClientMsg
+	15, // 14: hopbox.v1.WorkspaceService.SSH:input_type -> hopbox.v1.SSHClientMsg
+	18, // 15: hopbox.v1.WorkspaceService.Forward:input_type -> hopbox.v1.ForwardClientMsg
+	21, // 16: hopbox.v1.WorkspaceService.IssueSSHCert:input_type -> hopbox.v1.IssueSSHCertRequest
+	0,  // 17: hopbox.v1.WorkspaceService.CreateWorkspace:output_type -> hopbox.v1.Workspace
+	0,  // 18: hopbox.v1.WorkspaceService.GetWorkspace:output_type -> hopbox.v1.Workspace
+	6,  // 19: hopbox.v1.WorkspaceService.ListWorkspaces:output_type -> hopbox.v1.ListWorkspacesResponse
+	23, // 20: hopbox.v1.WorkspaceService.DeleteWorkspace:output_type -> google.protobuf.Empty
+	11, // 21: hopbox.v1.WorkspaceService.Shell:output_type -> hopbox.v1.ShellServerMsg
+	14, // 22: hopbox.v1.WorkspaceService.Exec:output_type -> hopbox.v1.ExecServerMsg
+	17, // 23: hopbox.v1.WorkspaceService.SSH:output_type -> hopbox.v1.SSHServerMsg
+	20, // 24: hopbox.v1.WorkspaceService.Forward:output_type -> hopbox.v1.ForwardServerMsg
+	22, // 25: hopbox.v1.WorkspaceService.IssueSSHCert:output_type -> hopbox.v1.IssueSSHCertResponse
+	17, // [17:26] is the sub-list for method output_type
+	8,  // [8:17] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_hopbox_v1_hopbox_proto_init() }
@@ -1497,13 +1716,17 @@ func file_hopbox_v1_hopbox_proto_init() {
 		(*SSHClientMsg_Open)(nil),
 		(*SSHClientMsg_Data)(nil),
 	}
+	file_hopbox_v1_hopbox_proto_msgTypes[18].OneofWrappers = []any{
+		(*ForwardClientMsg_Open)(nil),
+		(*ForwardClientMsg_Data)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hopbox_v1_hopbox_proto_rawDesc), len(file_hopbox_v1_hopbox_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   20,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -27,6 +27,7 @@ const (
 	WorkspaceService_Shell_FullMethodName           = "/hopbox.v1.WorkspaceService/Shell"
 	WorkspaceService_Exec_FullMethodName            = "/hopbox.v1.WorkspaceService/Exec"
 	WorkspaceService_SSH_FullMethodName             = "/hopbox.v1.WorkspaceService/SSH"
+	WorkspaceService_Forward_FullMethodName         = "/hopbox.v1.WorkspaceService/Forward"
 	WorkspaceService_IssueSSHCert_FullMethodName    = "/hopbox.v1.WorkspaceService/IssueSSHCert"
 )
 
@@ -43,6 +44,7 @@ type WorkspaceServiceClient interface {
 	Shell(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ShellClientMsg, ShellServerMsg], error)
 	Exec(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExecClientMsg, ExecServerMsg], error)
 	SSH(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SSHClientMsg, SSHServerMsg], error)
+	Forward(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ForwardClientMsg, ForwardServerMsg], error)
 	IssueSSHCert(ctx context.Context, in *IssueSSHCertRequest, opts ...grpc.CallOption) (*IssueSSHCertResponse, error)
 }
 
@@ -133,6 +135,19 @@ func (c *workspaceServiceClient) SSH(ctx context.Context, opts ...grpc.CallOptio
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type WorkspaceService_SSHClient = grpc.BidiStreamingClient[SSHClientMsg, SSHServerMsg]
 
+func (c *workspaceServiceClient) Forward(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ForwardClientMsg, ForwardServerMsg], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WorkspaceService_ServiceDesc.Streams[3], WorkspaceService_Forward_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ForwardClientMsg, ForwardServerMsg]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WorkspaceService_ForwardClient = grpc.BidiStreamingClient[ForwardClientMsg, ForwardServerMsg]
+
 func (c *workspaceServiceClient) IssueSSHCert(ctx context.Context, in *IssueSSHCertRequest, opts ...grpc.CallOption) (*IssueSSHCertResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(IssueSSHCertResponse)
@@ -156,6 +171,7 @@ type WorkspaceServiceServer interface {
 	Shell(grpc.BidiStreamingServer[ShellClientMsg, ShellServerMsg]) error
 	Exec(grpc.BidiStreamingServer[ExecClientMsg, ExecServerMsg]) error
 	SSH(grpc.BidiStreamingServer[SSHClientMsg, SSHServerMsg]) error
+	Forward(grpc.BidiStreamingServer[ForwardClientMsg, ForwardServerMsg]) error
 	IssueSSHCert(context.Context, *IssueSSHCertRequest) (*IssueSSHCertResponse, error)
 	mustEmbedUnimplementedWorkspaceServiceServer()
 }
@@ -188,6 +204,9 @@ func (UnimplementedWorkspaceServiceServer) Exec(grpc.BidiStreamingServer[ExecCli
 func (UnimplementedWorkspaceServiceServer) SSH(grpc.BidiStreamingServer[SSHClientMsg, SSHServerMsg]) error {
 	return status.Error(codes.Unimplemented, "method SSH not implemented")
 }
+func (UnimplementedWorkspaceServiceServer) Forward(grpc.BidiStreamingServer[ForwardClientMsg, ForwardServerMsg]) error {
+	return status.Error(codes.Unimplemented, "method Forward not implemented")
+}
 func (UnimplementedWorkspaceServiceServer) IssueSSHCert(context.Context, *IssueSSHCertRequest) (*IssueSSHCertResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method IssueSSHCert not implemented")
 }
@@ -305,6 +324,13 @@ func _WorkspaceService_SSH_Handler(srv interface{}, stream grpc.ServerStream) er
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type WorkspaceService_SSHServer = grpc.BidiStreamingServer[SSHClientMsg, SSHServerMsg]
 
+func _WorkspaceService_Forward_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WorkspaceServiceServer).Forward(&grpc.GenericServerStream[ForwardClientMsg, ForwardServerMsg]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WorkspaceService_ForwardServer = grpc.BidiStreamingServer[ForwardClientMsg, ForwardServerMsg]
+
 func _WorkspaceService_IssueSSHCert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IssueSSHCertRequest)
 	if err := dec(in); err != nil {
@@ -370,6 +396,12 @@ var WorkspaceService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Forward",
+			Handler:       _WorkspaceService_Forward_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "hopbox/v1/hopbox.proto",
 }
@@ -9,6 +9,7 @@ import (
 	"io"
 	"log"
 	"net"
+	"strings"
 	"sync"
 	"time"
 
@@ -25,6 +26,13 @@ type StateSink interface {
 	SetAgentConnected(ctx context.Context, workspaceID string, connected bool)
 }
 
+// RejectSink is optionally implemented by a StateSink to record why an agent
+// was turned away, so `hopbox status` can say so instead of a bare "agent not
+// connected".
+type RejectSink interface {
+	AgentRejected(ctx context.Context, workspaceID, reason string)
+}
+
 type Hub struct {
 	mu       sync.RWMutex
 	sessions map[string]*yamux.Session
@@ -115,7 +123,8 @@ func (h *Hub) OpenShell(ctx context.Context, workspaceID string, hdr agentproto.
 
 // OpenForward opens a yamux stream and asks the agent to dial 127.0.0.1:port
 // inside the workspace. The returned net.Conn is a raw pipe to that service —
-// hopbox-gw uses it to proxy an inbound request into the workspace.
+// hopbox-gw uses it to proxy an inbound request into the workspace. It has a
+// CloseWrite method, like a *net.TCPConn, to pass on a half-close.
 func (h *Hub) OpenForward(workspaceID string, port uint32) (net.Conn, error) {
 	stream, err := h.openStream(workspaceID)
 	if err != nil {
@@ -129,9 +138,16 @@ func (h *Hub) OpenForward(workspaceID string, port uint32) (net.Conn, error) {
 		_ = stream.Close()
 		return nil, fmt.Errorf("agenthub: write forward header: %w", err)
 	}
-	return stream, nil
+	return forwardStream{stream}, nil
 }
 
+// forwardStream is a forward's yamux stream. A yamux Close only sends FIN and
+// the stream stays readable until the agent closes its end, so it is exactly a
+// half-close: CloseWrite gives it the name callers look for.
+type forwardStream struct{ *yamux.Stream }
+
+func (s forwardStream) CloseWrite() error { return s.Stream.Close() }
+
 // OpenSSH opens a yamux stream the agent will serve an SSH server on. The
 // returned pipe carries the SSH wire protocol; the API bridges a client's
 // `hopbox proxy` to it.
@@ -211,6 +227,19 @@ func (h *Hub) accept(ctx context.Context, conn net.Conn) {
 		_ = conn.Close()
 		return
 	}
+	// The agent is side-loaded from hopboxd's own build, so a mismatch means a
+	// stale binary (a hand-built image, a box started by an older hopboxd):
+	// refuse it rather than misread its frames. Restarting the workspace stages
+	// the current agent.
+	if err := agentproto.CheckProtocol(hs.Protocol); err != nil {
+		log.Printf("agenthub: rejecting agent for workspace %s: %v (restart the workspace to update its agent)", wsID, err)
+		if rs, ok := h.sink.(RejectSink); ok {
+			reason := strings.TrimPrefix(err.Error(), "agentproto: ")
+			rs.AgentRejected(ctx, wsID, "agent rejected: "+reason+"; restart the workspace to update its agent")
+		}
+		_ = conn.Close()
+		return
+	}
 	// Shorter keepalive than the default so a dead agent's session is detected
 	// quickly — otherwise the duplicate-agent guard in Register would turn away a
 	// genuine reconnect until the stale session is noticed.
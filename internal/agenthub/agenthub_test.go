@@ -5,7 +5,9 @@ import (
 	"io"
 	"net"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/yamux"
 
@@ -181,3 +183,45 @@ func TestRegisterRefusesDuplicateLiveAgent(t *testing.T) {
 		t.Fatal("refused duplicate's teardown must not disconnect the real agent")
 	}
 }
+
+// An agent speaking a protocol hopboxd does not support is turned away at the
+// handshake: the hub closes the conn and never registers it.
+func TestServeRejectsIncompatibleAgent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink := &rejectSink{reasons: make(chan string, 1)}
+	hub := agenthub.New().WithResolver(func(context.Context, string) (string, error) { return "w1", nil }).WithSink(sink)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go hub.Serve(ctx, ln)
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if err := agentproto.WriteHandshake(conn, agentproto.Handshake{Token: "tok", Protocol: agentproto.Protocol + 1}); err != nil {
+		t.Fatal(err)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := conn.Read(make([]byte, 1)); err != io.EOF {
+		t.Fatal("hub should close the conn of an incompatible agent")
+	}
+	if hub.Connected("w1") {
+		t.Fatal("an incompatible agent must not be registered")
+	}
+	if r := <-sink.reasons; !strings.Contains(r, "w1: agent rejected: agent speaks protocol") {
+		t.Fatalf("rejection recorded as %q", r)
+	}
+}
+
+// rejectSink records AgentRejected calls as "<workspace>: <reason>".
+type rejectSink struct{ reasons chan string }
+
+func (*rejectSink) SetAgentConnected(context.Context, string, bool) {}
+func (s *rejectSink) AgentRejected(_ context.Context, id, reason string) {
+	s.reasons <- id + ": " + reason
+}
@@ -15,6 +15,28 @@ import (
 type Handshake struct {
 	WorkspaceID string `json:"workspace_id"`
 	Token       string `json:"token"`
+	Protocol    int    `json:"protocol,omitempty"` // agent's wire version; 0 = predates versioning (1)
+}
+
+// Protocol is the wire version this build speaks; hopboxd accepts agents from
+// MinProtocol through Protocol. Bump Protocol for any frame change an older
+// peer would misread, and raise MinProtocol once agents that predate it can no
+// longer be running.
+const (
+	Protocol    = 1
+	MinProtocol = 1
+)
+
+// CheckProtocol reports whether hopboxd can serve an agent that sent v in its
+// handshake.
+func CheckProtocol(v int) error {
+	if v == 0 {
+		v = 1
+	}
+	if v < MinProtocol || v > Protocol {
+		return fmt.Errorf("agentproto: agent speaks protocol %d, hopboxd supports %d-%d", v, MinProtocol, Protocol)
+	}
+	return nil
 }
 
 // Stream kinds carried by OpenFrame.
@@ -11,7 +11,7 @@ import (
 
 func TestHandshakeRoundTrip(t *testing.T) {
 	var buf bytes.Buffer
-	in := agentproto.Handshake{WorkspaceID: "w1", Token: "tok"}
+	in := agentproto.Handshake{WorkspaceID: "w1", Token: "tok", Protocol: agentproto.Protocol}
 	if err := agentproto.WriteHandshake(&buf, in); err != nil {
 		t.Fatalf("write: %v", err)
 	}
@@ -24,6 +24,15 @@ func TestHandshakeRoundTrip(t *testing.T) {
 	}
 }
 
+// Agents that predate versioning send no protocol and speak version 1.
+func TestCheckProtocol(t *testing.T) {
+	for v, ok := range map[int]bool{0: true, agentproto.Protocol: true, agentproto.Protocol + 1: false, -1: false} {
+		if err := agentproto.CheckProtocol(v); (err == nil) != ok {
+			t.Errorf("CheckProtocol(%d) = %v, want ok=%v", v, err, ok)
+		}
+	}
+}
+
 func TestShellHeaderRoundTrip(t *testing.T) {
 	var buf bytes.Buffer
 	in := agentproto.ShellHeader{Cmd: "/bin/bash", Cols: 80, Rows: 24}
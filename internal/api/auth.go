@@ -52,11 +52,20 @@ func authenticate(ctx context.Context, idp ports.Identity) (ports.Principal, err
 	return pr, nil
 }
 
+// publicMethod reports whether a method skips authentication: only the standard
+// gRPC health service, so load balancers can probe without a token.
+func publicMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/")
+}
+
 // AuthUnaryInterceptor authenticates every unary call with idp and injects the
 // caller's Principal. Install it only when multi-user auth is configured; with
 // no interceptor the server falls back to its default (open) principal.
 func AuthUnaryInterceptor(idp ports.Identity) grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if publicMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
 		pr, err := authenticate(ctx, idp)
 		if err != nil {
 			return nil, err
@@ -67,7 +76,10 @@ func AuthUnaryInterceptor(idp ports.Identity) grpc.UnaryServerInterceptor {
 
 // AuthStreamInterceptor is the streaming counterpart.
 func AuthStreamInterceptor(idp ports.Identity) grpc.StreamServerInterceptor {
-	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if publicMethod(info.FullMethod) {
+			return handler(srv, ss)
+		}
 		pr, err := authenticate(ss.Context(), idp)
 		if err != nil {
 			return err
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"net"
 	"time"
 
 	"golang.org/x/crypto/ssh"
@@ -31,6 +32,7 @@ type Hub interface {
 	OpenShell(ctx context.Context, workspaceID string, hdr agentproto.ShellHeader) (io.ReadWriteCloser, error)
 	OpenExec(workspaceID string, cmd []string) (io.ReadWriteCloser, error)
 	OpenSSH(workspaceID string) (io.ReadWriteCloser, error)
+	OpenForward(workspaceID string, port uint32) (net.Conn, error)
 }
 
 type Server struct {
@@ -81,7 +83,7 @@ func (s *Server) IssueSSHCert(ctx context.Context, req *hopboxv1.IssueSSHCertReq
 func toProto(w *workspace.Workspace) *hopboxv1.Workspace {
 	out := &hopboxv1.Workspace{
 		Id: w.ID, TenantId: w.TenantID, Owner: w.Owner, Name: w.Name,
-		ImageRef: w.ImageRef, MemMb: w.MemMB, Phase: string(w.Phase),
+		ImageRef: w.ImageRef, MemMb: w.MemMB, CpuMillis: w.CPUMillis, Phase: string(w.Phase),
 		AgentConnected: w.AgentConnected, Message: w.Message,
 	}
 	for _, e := range w.Endpoints {
@@ -123,9 +125,12 @@ func (s *Server) CreateWorkspace(ctx context.Context, r *hopboxv1.CreateWorkspac
 	if r.Name == "" || r.ImageRef == "" {
 		return nil, status.Error(codes.InvalidArgument, "name and image_ref are required")
 	}
+	if r.MemMb < 0 || r.CpuMillis < 0 {
+		return nil, status.Error(codes.InvalidArgument, "mem_mb and cpu_millis must not be negative")
+	}
 	pr := s.principal(ctx)
 	w := workspace.New(pr.TenantID, pr.ID, r.Name, r.ImageRef)
-	w.MemMB = r.MemMb
+	w.MemMB, w.CPUMillis = r.MemMb, r.CpuMillis
 	for _, ip := range r.Ingress {
 		if ip.Name == "" || ip.Port <= 0 {
 			return nil, status.Error(codes.InvalidArgument, "ingress entries need a name and port > 0")
@@ -197,7 +202,7 @@ func (s *Server) Exec(stream hopboxv1.WorkspaceService_ExecServer) error {
 		return err
 	}
 	if !s.hub.Connected(w.ID) {
-		return status.Errorf(codes.FailedPrecondition, "workspace %q agent not connected (phase=%s)", w.Name, w.Phase)
+		return agentNotConnected(w)
 	}
 	agentStream, err := s.hub.OpenExec(w.ID, open.Cmd)
 	if err != nil {
@@ -260,7 +265,7 @@ func (s *Server) Shell(stream hopboxv1.WorkspaceService_ShellServer) error {
 		return err
 	}
 	if !s.hub.Connected(w.ID) {
-		return status.Errorf(codes.FailedPrecondition, "workspace %q agent not connected (phase=%s)", w.Name, w.Phase)
+		return agentNotConnected(w)
 	}
 	agentStream, err := s.hub.OpenShell(ctx, w.ID, agentproto.ShellHeader{
 		Cmd: open.Cmd, Cols: uint16(open.Cols), Rows: uint16(open.Rows),
@@ -332,7 +337,7 @@ func (s *Server) SSH(stream hopboxv1.WorkspaceService_SSHServer) error {
 		return err
 	}
 	if !s.hub.Connected(w.ID) {
-		return status.Errorf(codes.FailedPrecondition, "workspace %q agent not connected (phase=%s)", w.Name, w.Phase)
+		return agentNotConnected(w)
 	}
 	agentStream, err := s.hub.OpenSSH(w.ID)
 	if err != nil {
@@ -380,3 +385,97 @@ func (s *Server) SSH(stream hopboxv1.WorkspaceService_SSHServer) error {
 	}
 	return err
 }
+
+// agentNotConnected is the error for a call that needs w's agent. It carries
+// the workspace's message, which says why when the hub refused the agent.
+func agentNotConnected(w *workspace.Workspace) error {
+	if w.Message != "" {
+		return status.Errorf(codes.FailedPrecondition, "workspace %q agent not connected (phase=%s): %s", w.Name, w.Phase, w.Message)
+	}
+	return status.Errorf(codes.FailedPrecondition, "workspace %q agent not connected (phase=%s)", w.Name, w.Phase)
+}
+
+// Forward bridges one TCP connection between the client (`hopbox forward`) and
+// a port inside the workspace, dialed by the agent on its loopback. Any port
+// works — it need not be exposed through ingress — so only the workspace's
+// owner can reach it, through the same authenticated API as Shell and SSH.
+func (s *Server) Forward(stream hopboxv1.WorkspaceService_ForwardServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	open := first.GetOpen()
+	if open == nil {
+		return status.Error(codes.InvalidArgument, "first Forward message must be `open`")
+	}
+	if open.Port == 0 || open.Port > 65535 {
+		return status.Errorf(codes.InvalidArgument, "invalid port %d", open.Port)
+	}
+	w, err := s.resolve(stream.Context(), open.NameOrId)
+	if err != nil {
+		return err
+	}
+	if !s.hub.Connected(w.ID) {
+		return agentNotConnected(w)
+	}
+	agentStream, err := s.hub.OpenForward(w.ID, open.Port)
+	if err != nil {
+		return status.Errorf(codes.Internal, "open forward: %v", err)
+	}
+	defer agentStream.Close()
+	// The client may half-close and still be reading, so the agent side can be
+	// the last one open: unblock its Read when the client goes away.
+	go func() {
+		<-stream.Context().Done()
+		_ = agentStream.SetReadDeadline(time.Now())
+	}()
+
+	errc := make(chan error, 2)
+	// agent -> client, until the agent side ends.
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, rerr := agentStream.Read(buf)
+			if n > 0 {
+				if serr := stream.Send(&hopboxv1.ForwardServerMsg{Data: append([]byte(nil), buf[:n]...)}); serr != nil {
+					errc <- serr
+					return
+				}
+			}
+			if rerr != nil {
+				errc <- rerr
+				return
+			}
+		}
+	}()
+	// client -> agent. The client's EOF is a half-close (see cmd/hopbox/forward.go):
+	// pass it on and leave the stream open for the service's reply.
+	go func() {
+		for {
+			msg, rerr := stream.Recv()
+			if errors.Is(rerr, io.EOF) {
+				if cw, ok := agentStream.(interface{ CloseWrite() error }); ok {
+					if werr := cw.CloseWrite(); werr != nil {
+						errc <- werr
+					}
+					return
+				}
+			}
+			if rerr != nil {
+				errc <- rerr
+				return
+			}
+			if d := msg.GetData(); d != nil {
+				if _, werr := agentStream.Write(d); werr != nil {
+					errc <- werr
+					return
+				}
+			}
+		}
+	}()
+	err = <-errc
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
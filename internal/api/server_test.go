@@ -13,6 +13,8 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
@@ -53,6 +55,7 @@ func TestMultiUserOwnerIsolation(t *testing.T) {
 		grpc.StreamInterceptor(api.AuthStreamInterceptor(idp)),
 	)
 	hopboxv1.RegisterWorkspaceServiceServer(gs, srv)
+	healthpb.RegisterHealthServer(gs, health.NewServer())
 	go func() { _ = gs.Serve(lis) }()
 	defer gs.Stop()
 
@@ -72,6 +75,18 @@ func TestMultiUserOwnerIsolation(t *testing.T) {
 	bob := client(grpc.WithPerRPCCredentials(testToken{"tok-bob"}))
 	anon := client()
 
+	// health checks are the one thing an anonymous caller may do
+	hconn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer hconn.Close()
+	if resp, err := healthpb.NewHealthClient(hconn).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil || resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("anonymous health check: %v %v", resp, err)
+	}
+
 	if _, err := alice.CreateWorkspace(ctx, &hopboxv1.CreateWorkspaceRequest{Name: "abox", ImageRef: "ubuntu:24.04"}); err != nil {
 		t.Fatalf("alice create: %v", err)
 	}
@@ -167,6 +182,12 @@ func (f *fakeHub) OpenSSH(string) (io.ReadWriteCloser, error) {
 	return c1, nil
 }
 
+func (f *fakeHub) OpenForward(string, uint32) (net.Conn, error) {
+	c1, c2 := net.Pipe()
+	go func() { _, _ = io.Copy(c2, c2) }() // echo, like OpenShell
+	return c1, nil
+}
+
 func (f *fakeHub) OpenExec(_ string, cmd []string) (io.ReadWriteCloser, error) {
 	c1, c2 := net.Pipe()
 	// far ("agent") end: emit "ran:<cmd>", echo any stdin back as stdout, exit 0.
@@ -191,12 +212,18 @@ func (f *fakeHub) OpenExec(_ string, cmd []string) (io.ReadWriteCloser, error) {
 }
 
 func dialer(t *testing.T) (hopboxv1.WorkspaceServiceClient, func()) {
+	t.Helper()
+	return dialHub(t, &fakeHub{connected: true})
+}
+
+// dialHub is dialer with the agent side played by hub.
+func dialHub(t *testing.T, hub api.Hub) (hopboxv1.WorkspaceServiceClient, func()) {
 	t.Helper()
 	s, err := sqlite.Open(t.TempDir() + "/api.db")
 	if err != nil {
 		t.Fatal(err)
 	}
-	srv := api.NewServer(s, &fakeHub{connected: true}, "default", "alice", nil)
+	srv := api.NewServer(s, hub, "default", "alice", nil)
 
 	lis := bufconn.Listen(1 << 20)
 	gs := grpc.NewServer()
@@ -241,6 +268,22 @@ func TestCreateGetListDelete(t *testing.T) {
 	}
 }
 
+func TestCreateWorkspaceLimits(t *testing.T) {
+	ctx := context.Background()
+	c, done := dialer(t)
+	defer done()
+	if _, err := c.CreateWorkspace(ctx, &hopboxv1.CreateWorkspaceRequest{Name: "neg", ImageRef: "ubuntu:24.04", CpuMillis: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("negative cpu: want InvalidArgument, got %v", err)
+	}
+	if _, err := c.CreateWorkspace(ctx, &hopboxv1.CreateWorkspaceRequest{Name: "big", ImageRef: "ubuntu:24.04", MemMb: 2048, CpuMillis: 1500}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.GetWorkspace(ctx, &hopboxv1.GetWorkspaceRequest{NameOrId: "big"})
+	if err != nil || got.MemMb != 2048 || got.CpuMillis != 1500 {
+		t.Fatalf("limits not persisted: %+v err=%v", got, err)
+	}
+}
+
 func TestGetWorkspaceNotFound(t *testing.T) {
 	ctx := context.Background()
 	c, done := dialer(t)
@@ -278,6 +321,109 @@ func TestShellBridgeEchoes(t *testing.T) {
 	}
 }
 
+func TestForwardBridgeEchoes(t *testing.T) {
+	ctx := context.Background()
+	c, done := dialer(t)
+	defer done()
+	_, _ = c.CreateWorkspace(ctx, &hopboxv1.CreateWorkspaceRequest{Name: "proj", ImageRef: "ubuntu:24.04"})
+
+	stream, err := c.Forward(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&hopboxv1.ForwardClientMsg{Msg: &hopboxv1.ForwardClientMsg_Open{
+		Open: &hopboxv1.ForwardOpen{NameOrId: "proj", Port: 9229},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&hopboxv1.ForwardClientMsg{Msg: &hopboxv1.ForwardClientMsg_Data{Data: []byte("ping")}}); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("recv: %v", err)
+	}
+	if string(msg.GetData()) != "ping" {
+		t.Fatalf("echo mismatch: %q", msg.GetData())
+	}
+}
+
+// replyHub's forwarded service reads the whole request, up to EOF, and only
+// then answers — like `nc -q` or an HTTP/1.0 server. The reply must still reach
+// a client that half-closed after sending.
+type replyHub struct{ fakeHub }
+
+func (h *replyHub) OpenForward(string, uint32) (net.Conn, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		defer ln.Close()
+		c, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		req, _ := io.ReadAll(c)
+		_, _ = c.Write(append([]byte("got "), req...))
+	}()
+	return net.Dial("tcp", ln.Addr().String())
+}
+
+func TestForwardReplyAfterHalfClose(t *testing.T) {
+	ctx := context.Background()
+	c, done := dialHub(t, &replyHub{fakeHub{connected: true}})
+	defer done()
+	_, _ = c.CreateWorkspace(ctx, &hopboxv1.CreateWorkspaceRequest{Name: "proj", ImageRef: "ubuntu:24.04"})
+
+	stream, err := c.Forward(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*hopboxv1.ForwardClientMsg{
+		{Msg: &hopboxv1.ForwardClientMsg_Open{Open: &hopboxv1.ForwardOpen{NameOrId: "proj", Port: 9229}}},
+		{Msg: &hopboxv1.ForwardClientMsg_Data{Data: []byte("ping")}},
+	} {
+		if err := stream.Send(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	var got []byte
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("recv: %v", err)
+		}
+		got = append(got, msg.GetData()...)
+	}
+	if string(got) != "got ping" {
+		t.Fatalf("reply after half-close = %q, want %q", got, "got ping")
+	}
+}
+
+func TestForwardRejectsBadPort(t *testing.T) {
+	ctx := context.Background()
+	c, done := dialer(t)
+	defer done()
+	stream, err := c.Forward(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = stream.Send(&hopboxv1.ForwardClientMsg{Msg: &hopboxv1.ForwardClientMsg_Open{
+		Open: &hopboxv1.ForwardOpen{NameOrId: "proj", Port: 70000},
+	}})
+	if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("want InvalidArgument, got %v", err)
+	}
+}
+
 // drainExec reads an exec stream to completion, returning combined stdout and
 // the exit code.
 func drainExec(t *testing.T, stream hopboxv1.WorkspaceService_ExecClient) (string, int32) {
@@ -4,10 +4,15 @@ package config
 import (
 	"flag"
 	"runtime"
+	"time"
 )
 
 type Config struct {
 	APIAddr        string // gRPC API listen (CLI clients)
+	APITLSCert     string // PEM certificate for the API listener; with APITLSKey enables TLS
+	APITLSKey      string // PEM private key for APITLSCert
+	APITLSSelfSign bool   // generate (once) a self-signed API certificate at APITLSCert/APITLSKey
+	APITLSHosts    string // comma-separated extra DNS names/IPs for the self-signed certificate
 	AgentListen    string // where agents dial in
 	AgentAdvertise string // address agents are told to dial (reachable from inside containers)
 	DBPath         string
@@ -15,6 +20,10 @@ type Config struct {
 	Tenant         string
 	Owner          string
 
+	APIReflection bool          // register gRPC server reflection (grpcurl) on the API
+	DrainDelay    time.Duration // on shutdown, how long to report NOT_SERVING while still serving, before draining
+	DrainTimeout  time.Duration // on shutdown, how long in-flight API calls get before being cut off
+
 	UsersFile          string // token->principal map enabling multi-user auth; empty = open single-user mode
 	SSHCAPath          string // path to the SSH user CA private key (created on first run); workspaces trust its public key
 	SSHCAPubFile       string // trust an EXTERNAL SSH CA (public key); disables built-in issuance (enterprise)
@@ -63,12 +72,23 @@ type Config struct {
 	AccountsFile    string  // registered-keys file (`<ssh-key> <account>`): keys here get persistent boxes; empty = all anonymous/ephemeral
 	MetaAddr        string  // box metadata API listen (boxes reach it by source IP; enables box-guest); empty = off
 	GuestBin        string  // host path of the box-guest binary to side-load into docker boxes; "" = none (microVM bakes it into the rootfs)
+
+	StatusAddr      string // read-only web status page listen; empty = off
+	StatusToken     string // token the status page requires; empty = the one in StatusTokenFile
+	StatusTokenFile string // where a generated status page token is kept (auto-created)
 }
 
 func Parse(args []string) (Config, error) {
 	fs := flag.NewFlagSet("hopboxd", flag.ContinueOnError)
 	var c Config
 	fs.StringVar(&c.APIAddr, "api-addr", ":7700", "gRPC API listen address")
+	fs.StringVar(&c.APITLSCert, "api-tls-cert", "", "PEM certificate (chain) for the API listener; set with --api-tls-key to serve TLS")
+	fs.StringVar(&c.APITLSKey, "api-tls-key", "", "PEM private key for --api-tls-cert")
+	fs.BoolVar(&c.APITLSSelfSign, "api-tls-self-signed", false, "serve TLS with a self-signed certificate, generated on first run (at --api-tls-cert/--api-tls-key, default ./hopbox-api-tls.{crt,key})")
+	fs.StringVar(&c.APITLSHosts, "api-tls-hosts", "", "comma-separated extra DNS names/IPs for the self-signed certificate (localhost, the hostname and the --api-addr host are always included)")
+	fs.BoolVar(&c.APIReflection, "api-reflection", false, "register gRPC server reflection on the API (for grpcurl; still authenticated)")
+	fs.DurationVar(&c.DrainDelay, "drain-delay", 0, "on shutdown, how long to keep serving while health reports NOT_SERVING, so load balancers stop routing here first")
+	fs.DurationVar(&c.DrainTimeout, "drain-timeout", 30*time.Second, "on shutdown, how long in-flight API calls (shells, execs) may run before being cut off")
 	fs.StringVar(&c.AgentListen, "agent-listen", ":7777", "agent reverse-dial listen address")
 	fs.StringVar(&c.AgentAdvertise, "agent-advertise", "host.docker.internal:7777", "address agents dial back to")
 	fs.StringVar(&c.DBPath, "db", "./hopbox.db", "sqlite database path")
@@ -117,6 +137,9 @@ func Parse(args []string) (Config, error) {
 	fs.StringVar(&c.AccountsFile, "accounts", "", "registered-keys file (`<ssh-key> <account>`): listed keys get persistent boxes, others are anonymous/ephemeral")
 	fs.StringVar(&c.MetaAddr, "meta-addr", "", "box metadata API listen address (enables box-guest: info/keep-alive/idle); boxes reach it by source IP; empty = off")
 	fs.StringVar(&c.GuestBin, "guest-bin", "", "host path of the box-guest binary to side-load into docker boxes (microVM bakes it into the rootfs)")
+	fs.StringVar(&c.StatusAddr, "status-addr", "", "read-only web status page listen address (e.g. 127.0.0.1:7702); empty disables")
+	fs.StringVar(&c.StatusToken, "status-token", "", "token required by the status page (Bearer, or the Basic auth password); empty = generate one into --status-token-file")
+	fs.StringVar(&c.StatusTokenFile, "status-token-file", "./hopbox-status-token", "status page token path, used when --status-token is empty (auto-created)")
 	fs.Int64Var(&c.SSHDefaultMemMB, "ssh-default-mem-mb", 2048, "memory cap (MB) for front-door boxes (anonymous; capped to limit abuse); 0 = unlimited")
 	fs.Float64Var(&c.SSHDefaultCPUs, "ssh-default-cpus", 2, "CPU cap (vCPU) for front-door boxes (anonymous; capped to limit abuse); 0 = unlimited")
 	if err := fs.Parse(args); err != nil {
@@ -78,8 +78,12 @@ func (r *Reconciler) Trigger(id, tenant string) {
 	}
 }
 
-// Run drives the hybrid loop until ctx is cancelled.
+// Run drives the hybrid loop until ctx is cancelled. Cancelling stops it taking
+// new work, but a step already under way (say, a Provision) runs to completion
+// rather than leaving a half-made box behind; Run returns once it has, so a
+// caller that waits for Run shuts down cleanly.
 func (r *Reconciler) Run(ctx context.Context) {
+	work := context.WithoutCancel(ctx)
 	t := time.NewTicker(r.cfg.Interval)
 	defer t.Stop()
 	for {
@@ -87,17 +91,20 @@ func (r *Reconciler) Run(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case req := <-r.events:
-			if err := r.ReconcileOne(ctx, req.tenant, req.id); err != nil {
+			if err := r.ReconcileOne(work, req.tenant, req.id); err != nil {
 				log.Printf("boxreconcile: %s (event): %v", req.id, err)
 			}
 		case <-t.C:
-			all, err := r.store.List(ctx, "")
+			all, err := r.store.List(work, "")
 			if err != nil {
 				log.Printf("boxreconcile: list: %v", err)
 				continue
 			}
 			for _, b := range all {
-				if err := r.ReconcileOne(ctx, b.TenantID, b.ID); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if err := r.ReconcileOne(work, b.TenantID, b.ID); err != nil {
 					log.Printf("boxreconcile: %s: %v", b.ID, err)
 				}
 			}
@@ -49,6 +49,48 @@ func TestReconcileProvisionsPending(t *testing.T) {
 	}
 }
 
+// blockingCompute holds Provision until release is closed.
+type blockingCompute struct {
+	fakeCompute
+	started, release chan struct{}
+	ctxErr           error
+}
+
+func (f *blockingCompute) Provision(ctx context.Context, r ports.ProvisionRequest) (ports.Instance, error) {
+	close(f.started)
+	<-f.release
+	f.ctxErr = ctx.Err()
+	return f.fakeCompute.Provision(ctx, r)
+}
+
+// Stopping Run mid-provision lets the provision finish and record the box,
+// and Run only returns after that.
+func TestRunFinishesStepOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	st := newFakeStore()
+	comp := &blockingCompute{started: make(chan struct{}), release: make(chan struct{})}
+	r := NewReconciler(st, comp, ReconcileConfig{AgentAddr: "host:7777", Interval: time.Hour})
+	b := New("default", "alice", "proj", "alpine")
+	_ = st.Create(ctx, b)
+
+	done := make(chan struct{})
+	go func() { r.Run(ctx); close(done) }()
+	r.Trigger(b.ID, "default")
+	<-comp.started
+	cancel()
+	select {
+	case <-done:
+		t.Fatal("Run returned with a provision still in flight")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(comp.release)
+	<-done
+	got, _ := st.Get(context.Background(), "default", b.ID)
+	if comp.ctxErr != nil || got.Phase != PhaseProvisioning || got.InstanceRef == "" {
+		t.Fatalf("after cancel: ctx err=%v phase=%s ref=%q", comp.ctxErr, got.Phase, got.InstanceRef)
+	}
+}
+
 type fakeHooks struct{ pre, post, preDestroy int }
 
 func (h *fakeHooks) PreProvision(_ context.Context, b *Box) ([]ports.Mount, map[string]string, error) {
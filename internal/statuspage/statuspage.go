@@ -0,0 +1,166 @@
+// Package statuspage is hopboxd's optional read-only web status page: every
+// workspace's phase, agent connection and endpoints, on one small page that
+// reads well on a phone. It changes nothing — it only lists the store. It
+// shows every owner's workspaces, so a token always gates it.
+package statuspage
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hopboxdev/hopbox/internal/core/workspace"
+)
+
+// Lister returns the workspaces to show (hopboxd passes store.ListAll).
+type Lister func(ctx context.Context) ([]*workspace.Workspace, error)
+
+// Server serves the status page.
+type Server struct {
+	list  Lister
+	token string // "" rejects every request
+	now   func() time.Time
+}
+
+// New builds the status page server. token must accompany every request in the
+// Authorization header: `Bearer <token>` for scripts, or as the Basic auth
+// password (any user name), which a browser prompts for and remembers. It is
+// never taken from the URL, where it would end up in logs and Referer headers.
+func New(list Lister, token string) *Server {
+	return &Server{list: list, token: token, now: time.Now}
+}
+
+// LoadOrCreateToken returns the token stored at path, generating and saving a
+// random one (mode 0600) on first use, for a page run without --status-token.
+func LoadOrCreateToken(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err == nil {
+		if tok := strings.TrimSpace(string(b)); tok != "" {
+			return tok, nil
+		}
+		return "", fmt.Errorf("statuspage: token file %s is empty", path)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	tok := hex.EncodeToString(raw)
+	if dir := filepath.Dir(path); dir != "" {
+		_ = os.MkdirAll(dir, 0o700)
+	}
+	if err := os.WriteFile(path, []byte(tok+"\n"), 0o600); err != nil {
+		return "", fmt.Errorf("statuspage: write token %s: %w", path, err)
+	}
+	return tok, nil
+}
+
+// Handler returns the routes: the HTML page and the same data as JSON.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.auth(s.page))
+	mux.HandleFunc("GET /status.json", s.auth(s.json))
+	return mux
+}
+
+func (s *Server) auth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			_, got, _ = r.BasicAuth()
+		}
+		if s.token == "" || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(got)), []byte(s.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="hopbox status", charset="UTF-8"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// row is one workspace as shown on the page / in status.json.
+type row struct {
+	Name      string   `json:"name"`
+	Owner     string   `json:"owner"`
+	Image     string   `json:"image"`
+	Phase     string   `json:"phase"`
+	Agent     bool     `json:"agent_connected"`
+	Endpoints []string `json:"endpoints"`
+	Message   string   `json:"message,omitempty"`
+	Updated   string   `json:"updated"`
+}
+
+func (s *Server) rows(ctx context.Context) ([]row, error) {
+	ws, err := s.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ws, func(i, j int) bool { return ws[i].Name < ws[j].Name })
+	out := make([]row, 0, len(ws))
+	for _, w := range ws {
+		r := row{
+			Name: w.Name, Owner: w.Owner, Image: w.ImageRef, Phase: string(w.Phase),
+			Agent: w.AgentConnected, Message: w.Message, Updated: w.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+		for _, e := range w.Endpoints {
+			r.Endpoints = append(r.Endpoints, e.URL)
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+func (s *Server) json(w http.ResponseWriter, r *http.Request) {
+	rows, err := s.rows(r.Context())
+	if err != nil {
+		log.Printf("statuspage: list workspaces: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"workspaces": rows})
+}
+
+func (s *Server) page(w http.ResponseWriter, r *http.Request) {
+	rows, err := s.rows(r.Context())
+	if err != nil {
+		log.Printf("statuspage: list workspaces: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = pageTmpl.Execute(w, map[string]any{"Rows": rows, "Now": s.now().UTC().Format(time.RFC1123)})
+}
+
+var pageTmpl = template.Must(template.New("page").Parse(`<!doctype html>
+<html><head><meta charset="utf-8"><meta name="viewport" content="width=device-width,initial-scale=1">
+<meta http-equiv="refresh" content="30"><title>hopbox status</title>
+<style>
+body{font:15px system-ui,sans-serif;margin:1em;color:#222}
+.ws{border:1px solid #ddd;border-radius:8px;padding:.6em .8em;margin:.6em 0}
+.ok{color:#17803d}.warn{color:#b45309}.bad{color:#b91c1c}
+small{color:#666}a{word-break:break-all}
+</style></head><body>
+<h1>hopbox</h1><small>{{.Now}} · refreshes every 30s</small>
+{{range .Rows}}<div class="ws">
+<b>{{.Name}}</b> <small>{{.Owner}} · {{.Image}}</small><br>
+{{if eq .Phase "Running"}}{{if .Agent}}<span class="ok">running</span>{{else}}<span class="warn">running, agent not connected</span>{{end}}{{else if eq .Phase "Failed"}}<span class="bad">failed</span>{{else}}<span class="warn">{{.Phase}}</span>{{end}}
+{{range .Endpoints}}<br><a href="{{.}}">{{.}}</a>{{end}}
+{{if .Message}}<br><small>{{.Message}}</small>{{end}}
+</div>{{else}}<p>No workspaces.</p>{{end}}
+</body></html>
+`))
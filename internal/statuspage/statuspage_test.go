@@ -0,0 +1,107 @@
+package statuspage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hopboxdev/hopbox/internal/core/box"
+	"github.com/hopboxdev/hopbox/internal/core/workspace"
+)
+
+func testServer(token string) *Server {
+	w := workspace.New("default", "alice", "web", "ubuntu:24.04")
+	w.Phase, w.AgentConnected = box.PhaseRunning, true
+	w.Endpoints = []workspace.Endpoint{{Name: "app", URL: "https://app-web.gw.example.com"}}
+	return New(func(context.Context) ([]*workspace.Workspace, error) {
+		return []*workspace.Workspace{w}, nil
+	}, token)
+}
+
+func get(h http.Handler, target string, hdr map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", target, nil)
+	for k, v := range hdr {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+var bearer = map[string]string{"Authorization": "Bearer s3cret"}
+
+func TestPageListsWorkspaces(t *testing.T) {
+	h := testServer("s3cret").Handler()
+	rec := get(h, "/", bearer)
+	if rec.Code != 200 || !strings.Contains(rec.Body.String(), "web") || !strings.Contains(rec.Body.String(), "https://app-web.gw.example.com") {
+		t.Fatalf("page: %d %s", rec.Code, rec.Body.String())
+	}
+	rec = get(h, "/status.json", bearer)
+	var doc struct{ Workspaces []row }
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Workspaces) != 1 || doc.Workspaces[0].Phase != "Running" || !doc.Workspaces[0].Agent {
+		t.Fatalf("status.json = %+v", doc)
+	}
+}
+
+func TestTokenRequired(t *testing.T) {
+	h := testServer("s3cret").Handler()
+	rec := get(h, "/", nil)
+	if rec.Code != http.StatusUnauthorized || !strings.HasPrefix(rec.Header().Get("WWW-Authenticate"), "Basic") {
+		t.Fatalf("no token: %d %q", rec.Code, rec.Header().Get("WWW-Authenticate"))
+	}
+	if rec := get(h, "/", map[string]string{"Authorization": "Bearer wrong"}); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("wrong token: %d", rec.Code)
+	}
+	if rec := get(h, "/?token=s3cret", nil); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("query token must not be accepted: %d", rec.Code)
+	}
+	if rec := get(h, "/status.json", bearer); rec.Code != 200 {
+		t.Fatalf("bearer token: %d", rec.Code)
+	}
+	req := httptest.NewRequest("GET", "/", nil)
+	req.SetBasicAuth("me", "s3cret")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != 200 {
+		t.Fatalf("basic auth password: %d", rec.Code)
+	}
+	if rec := get(testServer("").Handler(), "/", map[string]string{"Authorization": "Bearer "}); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("a server without a token must reject everything: %d", rec.Code)
+	}
+}
+
+// Store errors are logged, not shown to whoever loads the page.
+func TestListErrorNotLeaked(t *testing.T) {
+	h := New(func(context.Context) ([]*workspace.Workspace, error) {
+		return nil, errors.New("open /var/lib/hopbox/hopbox.db: permission denied")
+	}, "s3cret").Handler()
+	for _, path := range []string{"/", "/status.json"} {
+		rec := get(h, path, bearer)
+		if rec.Code != http.StatusInternalServerError || strings.Contains(rec.Body.String(), "hopbox.db") {
+			t.Fatalf("%s: %d %q", path, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestLoadOrCreateToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "status-token")
+	tok, err := LoadOrCreateToken(path)
+	if err != nil || len(tok) < 32 {
+		t.Fatalf("generated token %q, %v", tok, err)
+	}
+	if fi, err := os.Stat(path); err != nil || fi.Mode().Perm() != 0o600 {
+		t.Fatalf("token file mode: %v %v", fi, err)
+	}
+	if again, err := LoadOrCreateToken(path); err != nil || again != tok {
+		t.Fatalf("reloaded token %q, %v; want %q", again, err, tok)
+	}
+}
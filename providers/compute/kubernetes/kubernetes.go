@@ -93,10 +93,15 @@ func (p *Provider) Provision(ctx context.Context, r ports.ProvisionRequest) (por
 		Env:          env,
 		VolumeMounts: wsMounts,
 	}
-	if r.MemMB > 0 {
-		ws.Resources = corev1.ResourceRequirements{
-			Limits: corev1.ResourceList{corev1.ResourceMemory: *resource.NewQuantity(r.MemMB*1024*1024, resource.BinarySI)},
+	if r.MemMB > 0 || r.CPUMillis > 0 {
+		limits := corev1.ResourceList{}
+		if r.MemMB > 0 {
+			limits[corev1.ResourceMemory] = *resource.NewQuantity(r.MemMB*1024*1024, resource.BinarySI)
 		}
+		if r.CPUMillis > 0 {
+			limits[corev1.ResourceCPU] = *resource.NewMilliQuantity(r.CPUMillis, resource.DecimalSI)
+		}
+		ws.Resources = corev1.ResourceRequirements{Limits: limits}
 	}
 
 	name := podName(r.WorkspaceID)
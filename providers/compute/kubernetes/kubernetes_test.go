@@ -18,6 +18,7 @@ func sampleReq() ports.ProvisionRequest {
 		WorkspaceID: "w1",
 		ImageRef:    "ubuntu:24.04",
 		MemMB:       512,
+		CPUMillis:   1500,
 		Mounts:      []ports.Mount{{Source: "hopbox-home-w1", Target: "/home/dev"}},
 		Env:         map[string]string{"HOPBOX_AGENT_TOKEN": "tok", "HOPBOX_WORKSPACE_ID": "w1"},
 		Agent:       ports.AgentImage{ImageRef: "ghcr.io/hopboxdev/hopbox-agent:0.2.0", BinaryPath: "/hopbox-agent", TargetPath: "/hopbox/hopbox-agent"},
@@ -57,10 +58,13 @@ func TestProvisionBuildsAgentInjectingPod(t *testing.T) {
 	if ws.Image != "ubuntu:24.04" {
 		t.Fatalf("workspace image = %q", ws.Image)
 	}
-	// mem limit honored
+	// mem + cpu limits honored
 	if q, ok := ws.Resources.Limits[corev1.ResourceMemory]; !ok || q.Value() != 512*1024*1024 {
 		t.Fatalf("mem limit = %v", ws.Resources.Limits)
 	}
+	if q, ok := ws.Resources.Limits[corev1.ResourceCPU]; !ok || q.MilliValue() != 1500 {
+		t.Fatalf("cpu limit = %v", ws.Resources.Limits)
+	}
 	// the home mount became a PVC-claim volume (the seam: Mount.Source -> claimName)
 	var foundPVC bool
 	for _, v := range pod.Spec.Volumes {
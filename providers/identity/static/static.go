@@ -7,13 +7,17 @@ package static
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/hopboxdev/hopbox/internal/core/ports"
 )
 
 // Provider authenticates api-key credentials against a fixed key→Principal map.
 type Provider struct {
-	keys map[string]ports.Principal // api-key value -> principal
+	mu      sync.RWMutex
+	keys    map[string]ports.Principal // api-key value -> principal
+	expires map[string]time.Time       // api-key value -> expiry; absent = never
 }
 
 var _ ports.Identity = (*Provider)(nil)
@@ -28,6 +32,30 @@ func New(keys map[string]ports.Principal) *Provider {
 	return &Provider{keys: cp}
 }
 
+// WithExpiry gives some keys an expiry time, after which they no longer
+// authenticate — for long-lived automation tokens that should not live forever.
+// Keys without an entry never expire. The map is copied.
+func (p *Provider) WithExpiry(exp map[string]time.Time) *Provider {
+	cp := make(map[string]time.Time, len(exp))
+	for k, v := range exp {
+		cp[k] = v
+	}
+	p.mu.Lock()
+	p.expires = cp
+	p.mu.Unlock()
+	return p
+}
+
+// Replace swaps in a new key set and expiries, as after the users file is
+// edited: removed keys stop authenticating at once, without a restart. Both maps
+// are copied.
+func (p *Provider) Replace(keys map[string]ports.Principal, exp map[string]time.Time) {
+	np := New(keys).WithExpiry(exp)
+	p.mu.Lock()
+	p.keys, p.expires = np.keys, np.expires
+	p.mu.Unlock()
+}
+
 // privileged roles can do anything in the coarse MVP RBAC model.
 func privileged(role string) bool {
 	return role == "system" || role == "tenant-admin" || role == "owner"
@@ -40,10 +68,16 @@ func (p *Provider) Authenticate(_ context.Context, c ports.Credential) (ports.Pr
 	if c.Value == "" {
 		return ports.Principal{}, fmt.Errorf("static: empty credential")
 	}
+	p.mu.RLock()
 	pr, ok := p.keys[c.Value]
+	exp, expiring := p.expires[c.Value]
+	p.mu.RUnlock()
 	if !ok {
 		return ports.Principal{}, fmt.Errorf("static: unknown api key")
 	}
+	if expiring && !time.Now().Before(exp) {
+		return ports.Principal{}, fmt.Errorf("static: api key expired at %s", exp.Format(time.RFC3339))
+	}
 	return pr, nil
 }
 
@@ -3,6 +3,7 @@ package static
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/hopboxdev/hopbox/internal/core/ports"
 )
@@ -39,3 +40,29 @@ func TestAuthorizeCoarseRBAC(t *testing.T) {
 		t.Fatal("role-less principal must be denied")
 	}
 }
+
+func TestAuthenticateExpiry(t *testing.T) {
+	p := newProv().WithExpiry(map[string]time.Time{
+		"k-owner": time.Now().Add(-time.Minute),
+		"k-plain": time.Now().Add(time.Hour),
+	})
+	if _, err := p.Authenticate(context.Background(), ports.Credential{Value: "k-owner"}); err == nil {
+		t.Fatal("an expired key must not authenticate")
+	}
+	if pr, err := p.Authenticate(context.Background(), ports.Credential{Value: "k-plain"}); err != nil || pr.ID != "bob" {
+		t.Fatalf("unexpired key: %+v err=%v", pr, err)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	p := newProv()
+	p.Replace(map[string]ports.Principal{
+		"k-new": {ID: "carol", TenantID: "default", Roles: []string{"owner"}},
+	}, map[string]time.Time{"k-new": time.Now().Add(time.Hour)})
+	if _, err := p.Authenticate(context.Background(), ports.Credential{Value: "k-owner"}); err == nil {
+		t.Fatal("a removed key must stop authenticating")
+	}
+	if pr, err := p.Authenticate(context.Background(), ports.Credential{Value: "k-new"}); err != nil || pr.ID != "carol" {
+		t.Fatalf("added key: %+v err=%v", pr, err)
+	}
+}